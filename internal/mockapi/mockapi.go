@@ -0,0 +1,242 @@
+// Package mockapi implements an in-memory version of the todo API for tests
+// and local experimentation.
+package mockapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sudomateo/todo/todo"
+	"github.com/sudomateo/todo/todo/stores/todomemory"
+)
+
+// stores holds the in-memory stores of the mock todo API, keyed by host
+// name. Stores live for the lifetime of the provider process so that state
+// survives across provider configurations, such as the steps of an acceptance
+// test. Use distinct host names, such as mock://a and mock://b, for isolated
+// stores.
+var (
+	storesMu sync.Mutex
+	stores   = make(map[string]*todomemory.Store)
+)
+
+// Store returns the in-memory store for the mock host name, creating it
+// if needed.
+func Store(name string) *todomemory.Store {
+	storesMu.Lock()
+	defer storesMu.Unlock()
+
+	store, ok := stores[name]
+	if !ok {
+		store = todomemory.NewStore()
+		stores[name] = store
+	}
+
+	return store
+}
+
+// NewHandler returns an http.Handler implementing the todo API routes on
+// top of store, matching the status codes of the real server.
+//
+// Unlike the real server, which ignores it, creates honor the
+// X-Idempotency-Key header: a repeated key returns the todo created by the
+// first request instead of creating another one. This lets tests exercise
+// the idempotent_creates provider setting; it says nothing about the
+// behavior of the real server.
+func NewHandler(store todo.Storer) http.Handler {
+	mux := http.NewServeMux()
+
+	var (
+		idempotencyMu   sync.Mutex
+		idempotencyKeys = make(map[string]uuid.UUID)
+	)
+
+	mux.HandleFunc("GET /api/todo", func(w http.ResponseWriter, r *http.Request) {
+		todos, err := store.Query(r.Context())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, slices.Clone(todos))
+	})
+
+	mux.HandleFunc("POST /api/todo", func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("X-Idempotency-Key")
+		if key != "" {
+			idempotencyMu.Lock()
+			defer idempotencyMu.Unlock()
+
+			if id, ok := idempotencyKeys[key]; ok {
+				td, err := store.QueryByID(r.Context(), id)
+				if err != nil {
+					writeError(w, err)
+					return
+				}
+
+				writeJSON(w, http.StatusCreated, td)
+				return
+			}
+		}
+
+		var params todo.TodoCreateParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		if err := validate(params.Validate()); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		now := time.Now()
+		td := todo.Todo{
+			ID:          uuid.New(),
+			Text:        params.Text,
+			Priority:    params.Priority,
+			TimeCreated: now,
+			TimeUpdated: now,
+		}
+		if err := store.Create(r.Context(), td); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		if key != "" {
+			idempotencyKeys[key] = td.ID
+		}
+
+		writeJSON(w, http.StatusCreated, td)
+	})
+
+	mux.HandleFunc("GET /api/todo/{id}", func(w http.ResponseWriter, r *http.Request) {
+		td, ok := lookup(w, r, store)
+		if !ok {
+			return
+		}
+
+		writeJSON(w, http.StatusOK, td)
+	})
+
+	mux.HandleFunc("PATCH /api/todo/{id}", func(w http.ResponseWriter, r *http.Request) {
+		td, ok := lookup(w, r, store)
+		if !ok {
+			return
+		}
+
+		var params todo.TodoUpdateParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		if err := validate(params.Validate()); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		if params.Text != nil {
+			td.Text = *params.Text
+		}
+		if params.Priority != nil {
+			td.Priority = *params.Priority
+		}
+		if params.Completed != nil {
+			td.Completed = *params.Completed
+		}
+		td.TimeUpdated = time.Now()
+
+		if err := store.Update(r.Context(), td); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, td)
+	})
+
+	mux.HandleFunc("DELETE /api/todo/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, "invalid id format", http.StatusBadRequest)
+			return
+		}
+
+		// Like the real server, deleting a todo that does not exist succeeds.
+		td, err := store.QueryByID(r.Context(), id)
+		if errors.Is(err, todo.ErrNotFound) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		if err := store.Delete(r.Context(), td); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	return mux
+}
+
+// lookup returns the todo identified by the request path, writing an
+// error response and returning false if there is none.
+func lookup(w http.ResponseWriter, r *http.Request, store todo.Storer) (todo.Todo, bool) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "invalid id format", http.StatusBadRequest)
+		return todo.Todo{}, false
+	}
+
+	td, err := store.QueryByID(r.Context(), id)
+	if errors.Is(err, todo.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		return todo.Todo{}, false
+	}
+	if err != nil {
+		writeError(w, err)
+		return todo.Todo{}, false
+	}
+
+	return td, true
+}
+
+// validate returns the error of a Validate method of the upstream params
+// types. Those always return a todo.ValidationError, which is empty when the
+// params are valid.
+func validate(err error) error {
+	var vErr todo.ValidationError
+	if errors.As(err, &vErr) && vErr.Err == nil {
+		return nil
+	}
+
+	return err
+}
+
+// writeError writes an error response for err.
+func writeError(w http.ResponseWriter, err error) {
+	var vErr todo.ValidationError
+	if errors.As(err, &vErr) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -2,13 +2,29 @@ package main
 
 import (
 	"context"
+	"flag"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/sudomateo/terraform-provider-todo/todo"
 )
 
 func main() {
-	providerserver.Serve(context.Background(), todo.New, providerserver.ServeOpts{
+	var debug bool
+
+	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.Parse()
+
+	err := providerserver.Serve(context.Background(), todo.New, providerserver.ServeOpts{
 		Address: "sudomateo.dev/sudomateo/todo",
+		Debug:   debug,
 	})
+
+	// Summarize the API calls made by the provider once Terraform has shut it
+	// down.
+	todo.ReportStats()
+
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 }
@@ -0,0 +1,80 @@
+package todo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// apiError is returned when the todo API responds with an unexpected status
+// code. It carries the details needed to report the failure to the server
+// operators.
+type apiError struct {
+	// Operation describes the failed request, such as "creating todo".
+	Operation string
+
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Code is the error code reported by the API, or the status text if the
+	// API did not report one.
+	Code string
+
+	// Message is the error message reported by the API, or the raw response
+	// body if it was not a JSON error.
+	Message string
+
+	// RequestID is the ID the API assigned to the request, if any.
+	RequestID string
+}
+
+// newAPIError builds an apiError from an unexpected response and its body.
+func newAPIError(operation string, resp *http.Response, body []byte) *apiError {
+	e := apiError{
+		Operation:  operation,
+		StatusCode: resp.StatusCode,
+		Code:       strings.ToLower(http.StatusText(resp.StatusCode)),
+		Message:    strings.TrimSpace(string(body)),
+		RequestID:  resp.Header.Get("X-Request-Id"),
+	}
+
+	// Prefer the structured error fields when the API sent a JSON error.
+	var payload struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		if payload.Code != "" {
+			e.Code = payload.Code
+		}
+		if payload.Message != "" {
+			e.Message = payload.Message
+		}
+	}
+
+	return &e
+}
+
+// Error implements the error interface.
+func (e *apiError) Error() string {
+	details := e.Code
+	if e.RequestID != "" {
+		details += ", request-id " + e.RequestID
+	}
+
+	msg := fmt.Sprintf("failed %s: todo API returned %d (%s)", e.Operation, e.StatusCode, details)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	return msg
+}
+
+// isNotFound reports whether err is an apiError for a todo that does not
+// exist.
+func isNotFound(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,198 @@
+package todo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// oauthTokenRefreshMargin is how long before its expiry an OAuth 2.0 access
+// token is refreshed, so that it does not expire while a request is in
+// flight.
+const oauthTokenRefreshMargin = time.Minute
+
+// credentialsExecTimeout bounds a single run of a credentials command.
+const credentialsExecTimeout = time.Minute
+
+// authenticator adds credentials to requests to the todo API.
+type authenticator interface {
+	// authenticate adds credentials to req. It is called for every attempt
+	// of a request, so it may refresh expired credentials.
+	authenticate(ctx context.Context, req *http.Request) error
+}
+
+// bearerTokenAuth authenticates requests with a static API token.
+type bearerTokenAuth string
+
+// authenticate implements authenticator.
+func (t bearerTokenAuth) authenticate(_ context.Context, req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+string(t))
+	return nil
+}
+
+// basicAuth authenticates requests with HTTP basic authentication.
+type basicAuth struct {
+	username string
+	password string
+}
+
+// authenticate implements authenticator.
+func (a basicAuth) authenticate(_ context.Context, req *http.Request) error {
+	req.SetBasicAuth(a.username, a.password)
+	return nil
+}
+
+// oauthAuth authenticates requests with access tokens obtained through the
+// OAuth 2.0 client credentials grant, refreshing them before they expire so
+// that long applies keep working.
+type oauthAuth struct {
+	credentials oauthClientCredentials
+	http        *http.Client
+
+	mu    sync.Mutex
+	token oauthToken
+}
+
+// newOAuthAuth returns an oauthAuth requesting tokens with httpClient.
+func newOAuthAuth(credentials oauthClientCredentials, httpClient *http.Client) *oauthAuth {
+	return &oauthAuth{
+		credentials: credentials,
+		http:        httpClient,
+	}
+}
+
+// authenticate implements authenticator.
+func (a *oauthAuth) authenticate(ctx context.Context, req *http.Request) error {
+	token, err := a.currentToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", token.TokenType+" "+token.AccessToken)
+	return nil
+}
+
+// currentToken returns the current access token, requesting a new one if
+// there is none yet or it is about to expire.
+func (a *oauthAuth) currentToken(ctx context.Context) (oauthToken, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	valid := a.token.AccessToken != "" &&
+		(a.token.Expiry.IsZero() || time.Until(a.token.Expiry) > oauthTokenRefreshMargin)
+	if valid {
+		return a.token, nil
+	}
+
+	token, err := a.credentials.fetchToken(ctx, a.http)
+	if err != nil {
+		return oauthToken{}, err
+	}
+	a.token = token
+
+	return token, nil
+}
+
+// execAuth authenticates requests with a token printed by an external
+// command, such as a secret manager CLI. The command is run again when the
+// token it returned is about to expire.
+type execAuth struct {
+	command []string
+	env     map[string]string
+
+	mu    sync.Mutex
+	token oauthToken
+}
+
+// authenticate implements authenticator.
+func (a *execAuth) authenticate(ctx context.Context, req *http.Request) error {
+	token, err := a.currentToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", token.TokenType+" "+token.AccessToken)
+	return nil
+}
+
+// currentToken returns the current token, running the command if there is
+// none yet or it is about to expire.
+func (a *execAuth) currentToken(ctx context.Context) (oauthToken, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	valid := a.token.AccessToken != "" &&
+		(a.token.Expiry.IsZero() || time.Until(a.token.Expiry) > oauthTokenRefreshMargin)
+	if valid {
+		return a.token, nil
+	}
+
+	token, err := runCredentialsExec(ctx, a.command, a.env)
+	if err != nil {
+		return oauthToken{}, err
+	}
+	a.token = token
+
+	return token, nil
+}
+
+// runCredentialsExec runs command with the additional environment variables
+// env and parses the token from its standard output. The output is either
+// the bare token, or a JSON object with a token field and optional
+// token_type and RFC3339 expires_at fields.
+func runCredentialsExec(ctx context.Context, command []string, env map[string]string) (oauthToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, credentialsExecTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Env = os.Environ()
+	for key, value := range env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return oauthToken{}, fmt.Errorf("running credentials command %s: %w: %s", command[0], err, msg)
+		}
+		return oauthToken{}, fmt.Errorf("running credentials command %s: %w", command[0], err)
+	}
+
+	out = bytes.TrimSpace(out)
+	token := oauthToken{TokenType: "Bearer"}
+
+	if bytes.HasPrefix(out, []byte("{")) {
+		var payload struct {
+			Token     string    `json:"token"`
+			TokenType string    `json:"token_type"`
+			ExpiresAt time.Time `json:"expires_at"`
+		}
+		if err := json.Unmarshal(out, &payload); err != nil {
+			return oauthToken{}, fmt.Errorf("decoding output of credentials command %s: %w", command[0], err)
+		}
+
+		token.AccessToken = payload.Token
+		token.Expiry = payload.ExpiresAt
+		if payload.TokenType != "" {
+			token.TokenType = payload.TokenType
+		}
+	} else {
+		token.AccessToken = string(out)
+	}
+
+	if token.AccessToken == "" {
+		return oauthToken{}, fmt.Errorf("credentials command %s printed no token", command[0])
+	}
+
+	return token, nil
+}
@@ -0,0 +1,185 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sudomateo/todo/todo"
+)
+
+// Compile-time assertions that our concrete bulkCompleteAction implements the
+// necessary interfaces for an action.
+var (
+	_ action.Action                   = &bulkCompleteAction{}
+	_ action.ActionWithConfigure      = &bulkCompleteAction{}
+	_ action.ActionWithValidateConfig = &bulkCompleteAction{}
+)
+
+// NewBulkCompleteAction returns our implementation of this action.
+func NewBulkCompleteAction() action.Action {
+	return &bulkCompleteAction{}
+}
+
+// bulkCompleteAction is the concrete type that implements the Action
+// interface.
+type bulkCompleteAction struct {
+	client *apiClient
+}
+
+// bulkCompleteActionModel maps action schema data to a native Go type.
+type bulkCompleteActionModel struct {
+	Query    types.String `tfsdk:"query"`
+	MaxItems types.Int64  `tfsdk:"max_items"`
+}
+
+// Metadata returns the action type name.
+func (a *bulkCompleteAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bulk_complete"
+}
+
+// Schema defines the configuration for the action block.
+func (a *bulkCompleteAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Marks every open todo matching query as completed. " +
+			"The todo API has no batch endpoint, so the todos are completed one at a time with an update request each, " +
+			"so an error stops the action and todos completed before the error stay completed. " +
+			"Nothing is changed if more than max_items todos match.",
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				Required: true,
+				Description: "Space separated terms that must all match: is:open, priority:<priority>, " +
+					"or any other word to match against the todo text. Only open todos are completed, so is:completed is rejected.",
+			},
+			"max_items": schema.Int64Attribute{
+				Required: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig validates the query at plan time.
+func (a *bulkCompleteAction) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, resp *action.ValidateConfigResponse) {
+	var config bulkCompleteActionModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Query.IsNull() || config.Query.IsUnknown() {
+		return
+	}
+
+	if _, err := parseBulkCompleteQuery(config.Query.ValueString(), a.client.allowedPriorities()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("query"),
+			"Invalid todo query",
+			err.Error(),
+		)
+	}
+}
+
+// Invoke completes the matching todos.
+func (a *bulkCompleteAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config bulkCompleteActionModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := parseBulkCompleteQuery(config.Query.ValueString(), a.client.allowedPriorities())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("query"),
+			"Invalid todo query",
+			err.Error(),
+		)
+		return
+	}
+
+	todos, err := a.client.ListTodos(ctx)
+
+	// Warn if the todo API rate limit budget is running low.
+	resp.Diagnostics.Append(a.client.rateLimitDiagnostics()...)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read todos",
+			err.Error(),
+		)
+		return
+	}
+
+	matches := make([]todo.Todo, 0)
+	for _, td := range todos {
+		if !td.Completed && query.matches(td) {
+			matches = append(matches, td)
+		}
+	}
+
+	// Refuse to change anything when the query matches more than expected.
+	if int64(len(matches)) > config.MaxItems.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_items"),
+			"Too many matching todos",
+			fmt.Sprintf("The query matched %d open todos, more than max_items (%d). No todos were completed. "+
+				"Narrow the query or raise max_items.", len(matches), config.MaxItems.ValueInt64()),
+		)
+		return
+	}
+
+	// The todo API has no batch endpoint, so complete the todos one by one.
+	completed := true
+	for _, td := range matches {
+		if _, err := a.client.UpdateTodo(ctx, td.ID.String(), todo.TodoUpdateParams{Completed: &completed}); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating todo",
+				"Could not complete todo ID "+td.ID.String()+": "+err.Error(),
+			)
+			return
+		}
+
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Completed todo %s (%q)", td.ID, td.Text),
+		})
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Completed %d todos", len(matches)),
+	})
+}
+
+// parseBulkCompleteQuery parses the query of the action, rejecting queries
+// for completed todos, which would never match an open todo.
+func parseBulkCompleteQuery(value string, allowed priorities) (todoQuery, error) {
+	query, err := parseTodoQuery(value, allowed)
+	if err != nil {
+		return todoQuery{}, err
+	}
+
+	if query.completed != nil && *query.completed {
+		return todoQuery{}, errors.New("invalid term \"is:completed\": only open todos can be completed")
+	}
+
+	return query, nil
+}
+
+// Configure adds the provider configured client to the action.
+func (a *bulkCompleteAction) Configure(_ context.Context, req action.ConfigureRequest, _ *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	a.client = req.ProviderData.(*apiClient)
+}
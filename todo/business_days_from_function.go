@@ -0,0 +1,134 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Compile-time assertions that our concrete businessDaysFromFunction
+// implements the Function interface.
+var (
+	_ function.Function = &businessDaysFromFunction{}
+)
+
+// dateLayout is the layout of the plain dates accepted and returned by
+// functions.
+const dateLayout = "2006-01-02"
+
+// maxBusinessDays bounds the number of working days business_days_from
+// counts, which it does one day at a time. It is well over 300 years.
+const maxBusinessDays = 100000
+
+// NewBusinessDaysFromFunction returns our implementation of this function.
+func NewBusinessDaysFromFunction() function.Function {
+	return &businessDaysFromFunction{}
+}
+
+// businessDaysFromFunction is the concrete type that implements the Function
+// interface.
+type businessDaysFromFunction struct{}
+
+// Metadata returns the function name.
+func (f *businessDaysFromFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "business_days_from"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *businessDaysFromFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Add working days to a date",
+		Description: "Returns the date n working days after the given date, skipping Saturdays, Sundays and any given holidays. " +
+			"A negative n counts backwards, and n must be at most 100000 working days either way. The date may be a YYYY-MM-DD date or an RFC3339 timestamp, and the result uses the same format.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "date",
+				Description: "The date to count from.",
+			},
+			function.Int64Parameter{
+				Name:        "n",
+				Description: "The number of working days to add.",
+			},
+		},
+		VariadicParameter: function.ListParameter{
+			Name:        "holidays",
+			Description: "An optional list of YYYY-MM-DD dates that are not working days.",
+			ElementType: types.StringType,
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run computes the date.
+func (f *businessDaysFromFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var date string
+	var n int64
+	var holidayLists [][]string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &date, &n, &holidayLists))
+	if resp.Error != nil {
+		return
+	}
+
+	layout := dateLayout
+	start, err := time.Parse(layout, date)
+	if err != nil {
+		layout = time.RFC3339
+		start, err = time.Parse(layout, date)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, "invalid date "+date+": expected YYYY-MM-DD or an RFC3339 timestamp")
+			return
+		}
+	}
+
+	if n > maxBusinessDays || n < -maxBusinessDays {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("n must be between %d and %d", -maxBusinessDays, maxBusinessDays))
+		return
+	}
+
+	if len(holidayLists) > 1 {
+		resp.Error = function.NewArgumentFuncError(3, "at most one list of holidays may be given")
+		return
+	}
+
+	holidays := make(map[string]bool)
+	for _, list := range holidayLists {
+		for _, holiday := range list {
+			if _, err := time.Parse(dateLayout, holiday); err != nil {
+				resp.Error = function.NewArgumentFuncError(2, "invalid holiday "+holiday+": expected YYYY-MM-DD")
+				return
+			}
+			holidays[holiday] = true
+		}
+	}
+
+	due := addBusinessDays(start, n, holidays)
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, due.Format(layout)))
+}
+
+// addBusinessDays returns the date n working days after start, skipping
+// weekends and the YYYY-MM-DD dates in holidays. A negative n counts
+// backwards.
+func addBusinessDays(start time.Time, n int64, holidays map[string]bool) time.Time {
+	step := 1
+	if n < 0 {
+		step = -1
+		n = -n
+	}
+
+	due := start
+	for n > 0 {
+		due = due.AddDate(0, 0, step)
+
+		if due.Weekday() == time.Saturday || due.Weekday() == time.Sunday || holidays[due.Format(dateLayout)] {
+			continue
+		}
+
+		n--
+	}
+
+	return due
+}
@@ -0,0 +1,77 @@
+package todo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestBusinessDaysFromFunctionRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		date     string
+		n        int64
+		holidays []string
+		want     string
+		wantErr  bool
+	}{
+		{name: "zero", date: "2024-01-05", n: 0, want: "2024-01-05"},
+		{name: "within week", date: "2024-01-01", n: 3, want: "2024-01-04"},
+		{name: "over weekend", date: "2024-01-05", n: 1, want: "2024-01-08"},
+		{name: "from saturday", date: "2024-01-06", n: 1, want: "2024-01-08"},
+		{name: "two weeks", date: "2024-01-01", n: 10, want: "2024-01-15"},
+		{name: "backwards over weekend", date: "2024-01-08", n: -1, want: "2024-01-05"},
+		{name: "holiday", date: "2024-12-24", n: 1, holidays: []string{"2024-12-25", "2024-12-26"}, want: "2024-12-27"},
+		{name: "backwards over holiday", date: "2024-12-27", n: -1, holidays: []string{"2024-12-25", "2024-12-26"}, want: "2024-12-24"},
+		{name: "holiday on weekend", date: "2024-01-05", n: 1, holidays: []string{"2024-01-06"}, want: "2024-01-08"},
+		{name: "timestamp", date: "2024-01-05T09:30:00+01:00", n: 1, want: "2024-01-08T09:30:00+01:00"},
+		{name: "largest n", date: "2024-01-05", n: maxBusinessDays, want: "2407-04-27"},
+		{name: "smallest n", date: "2024-01-05", n: -maxBusinessDays, want: "1640-09-14"},
+		{name: "n too large", date: "2024-01-05", n: maxBusinessDays + 1, wantErr: true},
+		{name: "n too small", date: "2024-01-05", n: -maxBusinessDays - 1, wantErr: true},
+		{name: "invalid date", date: "01/05/2024", n: 1, wantErr: true},
+		{name: "invalid holiday", date: "2024-01-05", n: 1, holidays: []string{"25/12/2024"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holidays := types.TupleValueMust([]attr.Type{}, []attr.Value{})
+			if tt.holidays != nil {
+				values := make([]attr.Value, len(tt.holidays))
+				for i, holiday := range tt.holidays {
+					values[i] = types.StringValue(holiday)
+				}
+				holidays = types.TupleValueMust(
+					[]attr.Type{types.ListType{ElemType: types.StringType}},
+					[]attr.Value{types.ListValueMust(types.StringType, values)},
+				)
+			}
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(tt.date),
+					types.Int64Value(tt.n),
+					holidays,
+				}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			(&businessDaysFromFunction{}).Run(context.Background(), req, &resp)
+
+			if (resp.Error != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", resp.Error, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(tt.want)) {
+				t.Errorf("Run() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// circuitBreakerThreshold is the number of consecutive failed requests
+	// after which the circuit opens.
+	circuitBreakerThreshold = 5
+
+	// circuitBreakerCooldown is how long the circuit stays open before a
+	// single request is let through to probe whether the API has recovered.
+	circuitBreakerCooldown = 30 * time.Second
+)
+
+// circuitBreaker fails requests fast once the todo API has failed
+// consistently, so that a large apply does not wait on a timeout for every
+// remaining resource.
+type circuitBreaker struct {
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+	skipped  int
+}
+
+// allow reports whether a request may be sent. It returns an error describing
+// the outage when the circuit is open.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < circuitBreakerThreshold {
+		return nil
+	}
+
+	// Let a single request through once the cooldown has passed.
+	if !b.probing && time.Since(b.openedAt) >= circuitBreakerCooldown {
+		b.probing = true
+		return nil
+	}
+
+	b.skipped++
+	return fmt.Errorf("todo API unavailable after %d consecutive failed requests, %d operations skipped", b.failures, b.skipped)
+}
+
+// record records the outcome of a request that allow let through.
+func (b *circuitBreaker) record(resp *http.Response, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	// Cancellation is not a failure of the API.
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	if err == nil && resp.StatusCode < http.StatusInternalServerError {
+		b.failures = 0
+		b.skipped = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= circuitBreakerThreshold {
+		b.openedAt = time.Now()
+	}
+}
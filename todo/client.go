@@ -0,0 +1,794 @@
+package todo
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sudomateo/todo/todo"
+)
+
+// errNotModified is returned by conditional requests when the requested
+// todo has not changed.
+var errNotModified = errors.New("todo not modified")
+
+// errConflict is returned by conditional writes when the todo was modified
+// since it was last read.
+var errConflict = errors.New("todo was modified since it was last read")
+
+// apiClient is an HTTP client for the todo API. It speaks the same API as
+// todo.Client but accepts a context on every call so that cancelling a
+// Terraform operation also cancels its in-flight requests.
+type apiClient struct {
+	baseURL *url.URL
+	http    *http.Client
+	opts    apiClientOptions
+	breaker circuitBreaker
+
+	// inflight coalesces identical concurrent GET requests.
+	inflight inflightGroup
+
+	// sem limits the number of concurrent requests. It is nil when requests
+	// are not limited.
+	sem chan struct{}
+
+	// rateLimit tracks the rate limit budget reported by the API.
+	rateLimit rateLimitBudget
+
+	// failover holds the redundant deployments of the API requests fail
+	// over to. It is nil for a single deployment.
+	failover *failoverHosts
+
+	// endpoints caches the clients for other todo API endpoints returned by
+	// forEndpoint.
+	endpointsMu sync.Mutex
+	endpoints   map[string]*apiClient
+
+	// listCache holds the result of the last ListTodos call so that data
+	// sources read in the same run can share it. listCacheGeneration is
+	// bumped whenever the cache is invalidated, so that a list that was in
+	// flight during a write does not cache the todos from before the write.
+	listCacheMu         sync.Mutex
+	listCache           []todo.Todo
+	listCacheTime       time.Time
+	listCacheGeneration uint64
+}
+
+// listCacheTTL is how long a ListTodos result is reused before the API is
+// queried again.
+const listCacheTTL = 10 * time.Second
+
+// defaultRequestTimeout bounds each attempt of a request to the todo API,
+// unless configured otherwise.
+const defaultRequestTimeout = 10 * time.Second
+
+// defaultMaxIdleConns is the number of idle connections kept open to the
+// todo API, unless configured otherwise. Unlike the default of the standard
+// library, it applies per host, as nearly all requests go to a single host
+// and reopening connections beyond two idle ones per host exhausts ephemeral
+// ports on large plans.
+const defaultMaxIdleConns = 100
+
+// defaultKeepAlive is the interval of TCP keep-alive probes on connections
+// to the todo API, unless configured otherwise.
+const defaultKeepAlive = 30 * time.Second
+
+// dialTimeout bounds establishing a connection to the todo API.
+const dialTimeout = 30 * time.Second
+
+// newHTTPClient creates the HTTP client shared by every resource and data
+// source of a configured provider. It owns a dedicated connection pool so
+// connections to the todo API are reused across operations.
+func newHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = defaultMaxIdleConns
+	transport.MaxIdleConnsPerHost = defaultMaxIdleConns
+	transport.DialContext = (&net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: defaultKeepAlive,
+	}).DialContext
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   defaultRequestTimeout,
+	}
+}
+
+// apiClientOptions configures optional behavior of an apiClient.
+type apiClientOptions struct {
+	// compressRequests gzips request bodies. Response compression is always
+	// negotiated by the HTTP transport via Accept-Encoding.
+	compressRequests bool
+
+	// maxConcurrentRequests limits the number of requests in flight at once.
+	// Zero means no limit.
+	maxConcurrentRequests int
+
+	// rateLimitWarningThreshold is the remaining rate limit budget below
+	// which a warning is reported.
+	rateLimitWarningThreshold int64
+
+	// priorities are the priorities allowed for todos, a subset of the
+	// priority vocabulary of the API. Defaults to defaultPriorities.
+	priorities priorities
+
+	// defaultPriority is the priority of todos created without one.
+	// Defaults to the first of priorities.
+	defaultPriority todo.Priority
+
+	// auth adds credentials to every request. Nil sends unauthenticated
+	// requests.
+	auth authenticator
+
+	// maxRetries is the number of times an idempotent request is retried
+	// after a transient failure.
+	maxRetries int
+
+	// retryMaxWait caps the wait between retries. Zero retries right away.
+	retryMaxWait time.Duration
+
+	// idempotentCreates sends an idempotency key with every create, making
+	// creates retryable. Only enable it for servers that honor the key; the
+	// upstream todo API ignores it.
+	idempotentCreates bool
+
+	// throttle limits the rate of requests. It is shared with the clients
+	// of other endpoints. Nil means no limit.
+	throttle *requestThrottle
+
+	// userAgent is the User-Agent header of every request. Defaults to the
+	// Go HTTP client user agent.
+	userAgent string
+
+	// headers are added to every request. Headers set by the client itself
+	// take precedence.
+	headers http.Header
+
+	// signer signs every request. Nil sends unsigned requests.
+	signer *requestSigner
+
+	// stats collects statistics of the requests. It is shared with the
+	// clients of other endpoints. Nil collects no statistics.
+	stats *apiStats
+
+	// failoverURLs are the base URLs of redundant deployments of the API,
+	// starting with the base URL of the client, that requests fail over to
+	// when a server cannot be reached.
+	failoverURLs []string
+}
+
+// newAPIClient creates a new apiClient using rawURL as the base URL for the
+// todo API and httpClient to send requests.
+func newAPIClient(rawURL string, httpClient *http.Client, opts apiClientOptions) (*apiClient, error) {
+	baseURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	c := apiClient{
+		baseURL: baseURL,
+		http:    httpClient,
+		opts:    opts,
+	}
+
+	c.rateLimit.threshold = opts.rateLimitWarningThreshold
+
+	c.failover, err = newFailoverHosts(opts.failoverURLs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.opts.priorities) == 0 {
+		c.opts.priorities = defaultPriorities
+	}
+
+	if c.opts.defaultPriority == "" {
+		c.opts.defaultPriority = c.opts.priorities[0]
+	}
+
+	if opts.maxConcurrentRequests > 0 {
+		c.sem = make(chan struct{}, opts.maxConcurrentRequests)
+	}
+
+	return &c, nil
+}
+
+// idempotencyKeyHeader is the request header carrying the idempotency key of
+// a create request.
+const idempotencyKeyHeader = "X-Idempotency-Key"
+
+// apiLogSubsystem is the tflog subsystem of API traffic. Its level is set
+// with TF_LOG_PROVIDER_TODO_API.
+const apiLogSubsystem = "todo_api"
+
+// forEndpoint returns a client for the todo API at rawURL with the same
+// options and HTTP client as c, or c itself if rawURL is its base URL. Clients
+// are cached by normalized URL so that every resource using the same endpoint
+// shares its list cache, circuit breaker, and concurrency limit.
+func (c *apiClient) forEndpoint(rawURL string) (*apiClient, error) {
+	endpoint, err := normalizeEndpoint(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint == c.baseURL.String() {
+		return c, nil
+	}
+
+	c.endpointsMu.Lock()
+	defer c.endpointsMu.Unlock()
+
+	if client, ok := c.endpoints[endpoint]; ok {
+		return client, nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := c.http
+	if u.Scheme == mockScheme {
+		httpClient = newMockHTTPClient(u.Host)
+	}
+
+	// Other endpoints are separate servers, so they don't fail over to the
+	// provider hosts. They may also be run by someone else, so requests to
+	// them carry none of the provider credentials: no authentication, no
+	// signature, and no default headers, which may hold credentials too.
+	opts := c.opts
+	opts.failoverURLs = nil
+	opts.auth = nil
+	opts.signer = nil
+	opts.headers = nil
+
+	client, err := newAPIClient(endpoint, httpClient, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.endpoints == nil {
+		c.endpoints = make(map[string]*apiClient)
+	}
+	c.endpoints[endpoint] = client
+
+	return client, nil
+}
+
+// apiRequest describes a single request to the todo API.
+type apiRequest struct {
+	// operation describes the request in error messages.
+	operation string
+	method    string
+	url       *url.URL
+	header    http.Header
+	body      any
+
+	// wantStatus lists the response status codes that indicate success.
+	wantStatus []int
+}
+
+// ListTodos retrieves a list of all todos from the API. Results are cached
+// for listCacheTTL and the cache is invalidated by any write.
+func (c *apiClient) ListTodos(ctx context.Context) ([]todo.Todo, error) {
+	c.listCacheMu.Lock()
+	if c.listCache != nil && time.Since(c.listCacheTime) < listCacheTTL {
+		todos := slices.Clone(c.listCache)
+		c.listCacheMu.Unlock()
+		return todos, nil
+	}
+	generation := c.listCacheGeneration
+	c.listCacheMu.Unlock()
+
+	// Only coalesce lists started since the same invalidation, so that a
+	// list started after a write never shares the result of one started
+	// before it.
+	val, err := c.inflight.do(ctx, fmt.Sprintf("list %d", generation), func(ctx context.Context) (any, error) {
+		todos := make([]todo.Todo, 0)
+		_, err := c.do(ctx, apiRequest{
+			operation:  "listing todos",
+			method:     http.MethodGet,
+			url:        c.baseURL.JoinPath("/api/todo"),
+			wantStatus: []int{http.StatusOK},
+		}, &todos)
+		return todos, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The result is shared with any coalesced callers, so hand out a copy.
+	todos := slices.Clone(val.([]todo.Todo))
+
+	c.listCacheMu.Lock()
+	if c.listCacheGeneration == generation {
+		c.listCache = slices.Clone(todos)
+		c.listCacheTime = time.Now()
+	}
+	c.listCacheMu.Unlock()
+
+	return todos, nil
+}
+
+// EachTodo calls fn for every todo from the API, decoding the list one todo
+// at a time instead of holding the whole response in memory. A cached
+// ListTodos result is used if one is available, but the streamed result is
+// not cached.
+func (c *apiClient) EachTodo(ctx context.Context, fn func(todo.Todo) error) error {
+	c.listCacheMu.Lock()
+	if c.listCache != nil && time.Since(c.listCacheTime) < listCacheTTL {
+		todos := slices.Clone(c.listCache)
+		c.listCacheMu.Unlock()
+
+		for _, td := range todos {
+			if err := fn(td); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	c.listCacheMu.Unlock()
+
+	_, err := c.do(ctx, apiRequest{
+		operation:  "listing todos",
+		method:     http.MethodGet,
+		url:        c.baseURL.JoinPath("/api/todo"),
+		wantStatus: []int{http.StatusOK},
+	}, todoStream(fn))
+
+	return err
+}
+
+// todoStream decodes a JSON array of todos element by element, passing each
+// to the function.
+type todoStream func(todo.Todo) error
+
+// decodeStream implements streamDecoder.
+func (fn todoStream) decodeStream(dec *json.Decoder) error {
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok != json.Delim('[') {
+		return fmt.Errorf("expected a list of todos, got %v", tok)
+	}
+
+	for dec.More() {
+		var td todo.Todo
+		if err := dec.Decode(&td); err != nil {
+			return err
+		}
+
+		if err := fn(td); err != nil {
+			return err
+		}
+	}
+
+	_, err := dec.Token()
+	return err
+}
+
+// streamDecoder is implemented by response targets that decode the response
+// body incrementally.
+type streamDecoder interface {
+	decodeStream(dec *json.Decoder) error
+}
+
+// invalidateListCache discards the cached ListTodos result, and any result
+// of a list still in flight.
+func (c *apiClient) invalidateListCache() {
+	c.listCacheMu.Lock()
+	c.listCache = nil
+	c.listCacheGeneration++
+	c.listCacheMu.Unlock()
+}
+
+// GetTodo retrieves a single todo by its id from the API.
+func (c *apiClient) GetTodo(ctx context.Context, id string) (todo.Todo, error) {
+	td, _, err := c.GetTodoIfChanged(ctx, id, "")
+	return td.Todo, err
+}
+
+// GetTodoIfChanged retrieves a single todo by its id from the API unless it
+// still matches etag, in which case errNotModified is returned. It also
+// returns the ETag of the retrieved todo, if the API sent one.
+func (c *apiClient) GetTodoIfChanged(ctx context.Context, id string, etag string) (rawTodo, string, error) {
+	header := make(http.Header)
+	if etag != "" {
+		header.Set("If-None-Match", etag)
+	}
+
+	type result struct {
+		td   rawTodo
+		etag string
+	}
+
+	val, err := c.inflight.do(ctx, "get "+id+" "+etag, func(ctx context.Context) (any, error) {
+		var td rawTodo
+		resp, err := c.do(ctx, apiRequest{
+			operation:  "getting todo",
+			method:     http.MethodGet,
+			url:        c.baseURL.JoinPath("/api/todo", id),
+			header:     header,
+			wantStatus: []int{http.StatusOK, http.StatusNotModified},
+		}, &td)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusNotModified {
+			return nil, errNotModified
+		}
+
+		return result{td: td, etag: resp.Header.Get("ETag")}, nil
+	})
+	if errors.Is(err, errNotModified) {
+		return rawTodo{}, etag, err
+	}
+	if err != nil {
+		return rawTodo{}, "", err
+	}
+
+	r := val.(result)
+	return r.td, r.etag, nil
+}
+
+// CreateTodo creates a todo.
+func (c *apiClient) CreateTodo(ctx context.Context, params todo.TodoCreateParams) (todo.Todo, error) {
+	td, err := c.CreateTodoWithExtra(ctx, params, nil)
+	return td.Todo, err
+}
+
+// CreateTodoWithExtra creates a todo, sending extra fields along with params.
+//
+// Creates are not retried unless idempotent creates are enabled, in which case
+// every call sends a new idempotency key that is reused when the request is
+// retried. This only prevents duplicates on servers that honor the key, and
+// not across separate calls, such as a create repeated by a later apply.
+func (c *apiClient) CreateTodoWithExtra(ctx context.Context, params todo.TodoCreateParams, extra map[string]json.RawMessage) (rawTodo, error) {
+	defer c.invalidateListCache()
+
+	header := make(http.Header)
+	if c.opts.idempotentCreates {
+		header.Set(idempotencyKeyHeader, uuid.NewString())
+	}
+
+	var td rawTodo
+	_, err := c.do(ctx, apiRequest{
+		operation:  "creating todo",
+		method:     http.MethodPost,
+		url:        c.baseURL.JoinPath("/api/todo"),
+		header:     header,
+		body:       todoPayload{params: params, extra: extra},
+		wantStatus: []int{http.StatusCreated},
+	}, &td)
+	if err != nil {
+		return rawTodo{}, err
+	}
+
+	return td, nil
+}
+
+// UpdateTodo updates an existing todo given by id.
+func (c *apiClient) UpdateTodo(ctx context.Context, id string, params todo.TodoUpdateParams) (todo.Todo, error) {
+	td, err := c.UpdateTodoIfUnmodified(ctx, id, params, nil, time.Time{})
+	return td.Todo, err
+}
+
+// UpdateTodoIfUnmodified updates an existing todo given by id, sending extra
+// fields along with params, unless it was modified after since, in which case
+// errConflict is returned. A zero since updates the todo unconditionally.
+func (c *apiClient) UpdateTodoIfUnmodified(ctx context.Context, id string, params todo.TodoUpdateParams, extra map[string]json.RawMessage, since time.Time) (rawTodo, error) {
+	defer c.invalidateListCache()
+
+	var td rawTodo
+	resp, err := c.do(ctx, apiRequest{
+		operation:  "updating todo",
+		method:     http.MethodPatch,
+		url:        c.baseURL.JoinPath("/api/todo", id),
+		header:     unmodifiedSinceHeader(since),
+		body:       todoPayload{params: params, extra: extra},
+		wantStatus: []int{http.StatusOK, http.StatusPreconditionFailed},
+	}, &td)
+	if err != nil {
+		return rawTodo{}, err
+	}
+
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return rawTodo{}, errConflict
+	}
+
+	return td, nil
+}
+
+// DeleteTodo deletes a todo by its id.
+func (c *apiClient) DeleteTodo(ctx context.Context, id string) error {
+	return c.DeleteTodoIfUnmodified(ctx, id, time.Time{})
+}
+
+// DeleteTodoIfUnmodified deletes a todo by its id unless it was modified
+// after since, in which case errConflict is returned. A zero since deletes the
+// todo unconditionally.
+func (c *apiClient) DeleteTodoIfUnmodified(ctx context.Context, id string, since time.Time) error {
+	defer c.invalidateListCache()
+
+	resp, err := c.do(ctx, apiRequest{
+		operation:  "deleting todo",
+		method:     http.MethodDelete,
+		url:        c.baseURL.JoinPath("/api/todo", id),
+		header:     unmodifiedSinceHeader(since),
+		wantStatus: []int{http.StatusNoContent, http.StatusPreconditionFailed},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return errConflict
+	}
+
+	return nil
+}
+
+// unmodifiedSinceHeader returns the If-Unmodified-Since header for since, or
+// no header if since is zero. HTTP dates only have second precision, so since
+// is rounded up to the next second to avoid spurious conflicts with a todo
+// that was last updated partway through a second.
+func unmodifiedSinceHeader(since time.Time) http.Header {
+	header := make(http.Header)
+	if since.IsZero() {
+		return header
+	}
+
+	if rounded := since.Truncate(time.Second); !rounded.Equal(since) {
+		since = rounded.Add(time.Second)
+	}
+	header.Set("If-Unmodified-Since", since.UTC().Format(http.TimeFormat))
+
+	return header
+}
+
+// do sends a request to the API and decodes a successful response body into
+// out, if out is not nil and the response has a body. The returned response
+// has its body closed and is only useful for its status and headers.
+func (c *apiClient) do(ctx context.Context, r apiRequest, out any) (*http.Response, error) {
+	var body []byte
+	if r.body != nil {
+		buf := new(bytes.Buffer)
+		if err := json.NewEncoder(buf).Encode(r.body); err != nil {
+			return nil, err
+		}
+		body = buf.Bytes()
+	}
+
+	if body != nil && c.opts.compressRequests {
+		buf := new(bytes.Buffer)
+		zw := gzip.NewWriter(buf)
+		if _, err := zw.Write(body); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		body = buf.Bytes()
+	}
+
+	ctx = tflog.NewSubsystem(ctx, apiLogSubsystem)
+
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+
+	var resp *http.Response
+	var err error
+	defer func() { c.breaker.record(resp, err) }()
+
+	failovers := 0
+	resigned := false
+	for attempt := 1; ; attempt++ {
+		host, target := c.failover.current(r.url)
+
+		var req *http.Request
+		req, err = http.NewRequestWithContext(ctx, r.method, target.String(), bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+
+		for key, values := range c.opts.headers {
+			req.Header[key] = values
+		}
+		for key, values := range r.header {
+			req.Header[key] = values
+		}
+		if c.opts.userAgent != "" {
+			req.Header.Set("User-Agent", c.opts.userAgent)
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+			if c.opts.compressRequests {
+				req.Header.Set("Content-Encoding", "gzip")
+			}
+		}
+
+		if c.opts.auth != nil {
+			if err = c.opts.auth.authenticate(ctx, req); err != nil {
+				return nil, err
+			}
+		}
+
+		if c.opts.signer != nil {
+			c.opts.signer.sign(req, body)
+		}
+
+		if c.opts.throttle != nil {
+			if err = c.opts.throttle.wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		if err = c.acquire(ctx); err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		resp, err = c.http.Do(req)
+		elapsed := time.Since(start)
+		if c.opts.stats != nil {
+			c.opts.stats.record(r.operation, attempt, elapsed, resp, err)
+		}
+		c.release()
+
+		fields := map[string]any{
+			"method":   r.method,
+			"path":     r.url.Path,
+			"duration": elapsed.String(),
+			"retries":  attempt - 1,
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+			fields["request_id"] = resp.Header.Get("X-Request-Id")
+			c.rateLimit.observe(resp)
+		}
+		tflog.SubsystemDebug(ctx, apiLogSubsystem, "todo API request", fields)
+
+		// Send requests that could not reach the server to the next host.
+		// Failing over does not use up a retry.
+		if err != nil && isConnectError(err) && failovers < c.failover.len()-1 {
+			failovers++
+			next := c.failover.failover(host)
+			tflog.SubsystemWarn(ctx, apiLogSubsystem, "todo API host unreachable, failing over", map[string]any{
+				"failed": target.Host,
+				"next":   next.Host,
+				"error":  err.Error(),
+			})
+			attempt--
+			continue
+		}
+
+		// Sign and send requests again once if the server rejected the
+		// signature because of clock skew. The server did not apply the
+		// request, so this does not use up a retry either.
+		if err == nil && c.opts.signer != nil && !resigned && c.opts.signer.correctSkew(resp) {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+
+			resigned = true
+			tflog.SubsystemWarn(ctx, apiLogSubsystem, "todo API rejected request signature, correcting for clock skew", map[string]any{
+				"date": resp.Header.Get("Date"),
+			})
+			attempt--
+			continue
+		}
+
+		// Retry idempotent requests that failed due to a transient error,
+		// unless the caller retries the whole call.
+		if isIdempotent(req) && attempt <= c.opts.maxRetries && !transientRetriesDisabled(ctx) && isTransient(resp, err) {
+			if resp != nil {
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
+
+			if err = sleepWithContext(ctx, transientRetryWait(attempt, c.opts.retryMaxWait, resp)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		break
+	}
+	defer resp.Body.Close()
+
+	if !slices.Contains(r.wantStatus, resp.StatusCode) {
+		// The body only adds detail to the error, so a failure to read it is
+		// not reported.
+		body, _ := io.ReadAll(resp.Body)
+
+		return resp, newAPIError(r.operation, resp, body)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotModified {
+		return resp, nil
+	}
+
+	if sd, ok := out.(streamDecoder); ok {
+		return resp, sd.decodeStream(json.NewDecoder(resp.Body))
+	}
+
+	return resp, json.NewDecoder(resp.Body).Decode(out)
+}
+
+// allowedPriorities returns the priorities allowed for todos. It may be
+// called on a nil client, before the provider is configured, in which case
+// the allowed priorities are not known yet and every priority of the API is
+// returned, since allowed priorities are a subset of them.
+func (c *apiClient) allowedPriorities() priorities {
+	if c == nil {
+		return defaultPriorities
+	}
+
+	return c.opts.priorities
+}
+
+// externalHTTPClient returns the HTTP client for requests to servers other
+// than the todo API, such as OAuth 2.0 token endpoints: the configured client,
+// honoring the TLS and proxy settings of the provider, unless the client only
+// reaches the mock todo API. It may be called on a nil client, before the
+// provider is configured, in which case a client with default settings is
+// returned.
+func (c *apiClient) externalHTTPClient() *http.Client {
+	if c == nil || c.baseURL.Scheme == mockScheme {
+		return newHTTPClient()
+	}
+
+	return c.http
+}
+
+// defaultPriority returns the priority of todos created without one.
+func (c *apiClient) defaultPriority() todo.Priority {
+	return c.opts.defaultPriority
+}
+
+// rateLimitDiagnostics returns a warning, once per client, when the API rate
+// limit budget is running low.
+func (c *apiClient) rateLimitDiagnostics() diag.Diagnostics {
+	return c.rateLimit.diagnostics()
+}
+
+// acquire waits for a free request slot when requests are limited.
+func (c *apiClient) acquire(ctx context.Context) error {
+	if c.sem == nil {
+		return nil
+	}
+
+	select {
+	case c.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees the request slot taken by acquire.
+func (c *apiClient) release() {
+	if c.sem == nil {
+		return
+	}
+
+	<-c.sem
+}
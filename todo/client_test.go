@@ -0,0 +1,173 @@
+package todo
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sudomateo/terraform-provider-todo/internal/mockapi"
+	"github.com/sudomateo/todo/todo"
+)
+
+// recordingTransport serves requests from the mock todo API for the host of
+// each request, recording the requests it serves.
+type recordingTransport struct {
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.requests = append(t.requests, req)
+	t.mu.Unlock()
+
+	return mockTransport{handler: mockapi.NewHandler(mockapi.Store(req.URL.Host))}.RoundTrip(req)
+}
+
+// last returns the last recorded request.
+func (t *recordingTransport) last() *http.Request {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.requests[len(t.requests)-1]
+}
+
+func TestAPIClientForEndpoint(t *testing.T) {
+	transport := &recordingTransport{}
+
+	c, err := newAPIClient("https://provider.for-endpoint.test", &http.Client{Transport: transport}, apiClientOptions{
+		auth:    bearerTokenAuth("provider-token"),
+		signer:  newRequestSigner("provider-key"),
+		headers: http.Header{"X-Tenant": []string{"provider-tenant"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		endpoint    string
+		wantForeign bool
+	}{
+		{name: "provider host", endpoint: "https://provider.for-endpoint.test"},
+		{name: "provider host with trailing slash", endpoint: "https://provider.for-endpoint.test/"},
+		{name: "provider host without scheme", endpoint: "provider.for-endpoint.test"},
+		{name: "other host", endpoint: "https://other.for-endpoint.test", wantForeign: true},
+		{name: "other host with trailing slash", endpoint: "https://other.for-endpoint.test/", wantForeign: true},
+	}
+
+	var foreign *apiClient
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := c.forEndpoint(tt.endpoint)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !tt.wantForeign {
+				if client != c {
+					t.Fatalf("forEndpoint(%q) returned a new client for the provider host", tt.endpoint)
+				}
+			} else {
+				if client == c {
+					t.Fatalf("forEndpoint(%q) returned the provider client", tt.endpoint)
+				}
+				if foreign == nil {
+					foreign = client
+				} else if client != foreign {
+					t.Fatalf("forEndpoint(%q) did not reuse the cached client", tt.endpoint)
+				}
+			}
+
+			client.invalidateListCache()
+			if _, err := client.ListTodos(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			req := transport.last()
+
+			credentials := map[string]string{
+				"Authorization": req.Header.Get("Authorization"),
+				signatureHeader: req.Header.Get(signatureHeader),
+				"X-Tenant":      req.Header.Get("X-Tenant"),
+			}
+			for header, value := range credentials {
+				if tt.wantForeign && value != "" {
+					t.Errorf("request to %s has provider header %s", req.URL.Host, header)
+				}
+				if !tt.wantForeign && value == "" {
+					t.Errorf("request to %s is missing provider header %s", req.URL.Host, header)
+				}
+			}
+		})
+	}
+
+	if _, err := c.forEndpoint("ftp://other.for-endpoint.test"); err == nil {
+		t.Error("forEndpoint accepted an invalid endpoint")
+	}
+}
+
+// slowListTransport serves requests from the mock todo API at name, holding
+// back the response to the first list request until release is closed, as if
+// it was slow to arrive.
+type slowListTransport struct {
+	name    string
+	listed  chan struct{}
+	release chan struct{}
+	lists   atomic.Int32
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *slowListTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := mockTransport{handler: mockapi.NewHandler(mockapi.Store(t.name))}.RoundTrip(req)
+
+	if req.Method == http.MethodGet && req.URL.Path == "/api/todo" && t.lists.Add(1) == 1 {
+		close(t.listed)
+		<-t.release
+	}
+
+	return resp, err
+}
+
+func TestAPIClientListTodosInvalidatedWhileInFlight(t *testing.T) {
+	name := newMockName("list-cache")
+
+	now := time.Now()
+	seedMockTodos(t, name, todo.Todo{Text: "Buy milk", Priority: todo.PriorityLow, TimeCreated: now, TimeUpdated: now})
+
+	transport := &slowListTransport{name: name, listed: make(chan struct{}), release: make(chan struct{})}
+	c, err := newAPIClient(mockScheme+"://"+name, &http.Client{Transport: transport}, apiClientOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := c.ListTodos(context.Background())
+		errc <- err
+	}()
+
+	// Create a todo while the list from before the create is in flight.
+	<-transport.listed
+	if _, err := c.CreateTodo(context.Background(), todo.TodoCreateParams{Text: "Buy eggs", Priority: todo.PriorityLow}); err != nil {
+		t.Fatal(err)
+	}
+	close(transport.release)
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+
+	todos, err := c.ListTodos(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(todos) != 2 {
+		t.Errorf("ListTodos() after the create returned %d todos, want 2", len(todos))
+	}
+	if got := transport.lists.Load(); got != 2 {
+		t.Errorf("list requests = %d, want 2", got)
+	}
+}
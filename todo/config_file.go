@@ -0,0 +1,53 @@
+package todo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// todoConfigFile is the format of the todo configuration file shared with
+// other todo API clients, such as CLIs.
+type todoConfigFile struct {
+	Endpoint     string `json:"endpoint"`
+	Token        string `json:"token"`
+	Username     string `json:"username"`
+	Password     string `json:"password"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	TokenURL     string `json:"token_url"`
+}
+
+// loadConfigFile reads the todo configuration file at the path given by the
+// TODO_CONFIG_FILE environment variable, or at ~/.todo/config.json. A missing
+// file at the default path is not an error and yields an empty
+// configuration.
+func loadConfigFile() (todoConfigFile, string, error) {
+	path := os.Getenv("TODO_CONFIG_FILE")
+	explicit := path != ""
+	if !explicit {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return todoConfigFile{}, "", nil
+		}
+		path = filepath.Join(home, ".todo", "config.json")
+	}
+
+	buf, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return todoConfigFile{}, "", nil
+	}
+	if err != nil {
+		return todoConfigFile{}, path, err
+	}
+
+	var file todoConfigFile
+	if err := json.Unmarshal(buf, &file); err != nil {
+		return todoConfigFile{}, path, fmt.Errorf("decoding %s: %w", path, err)
+	}
+
+	return file, path, nil
+}
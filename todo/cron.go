@@ -0,0 +1,212 @@
+package todo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// cronMacros maps the nonstandard cron macros to their five field
+// equivalents.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronMonthNames and cronDayNames map the names accepted in the month and
+// day of week fields to their numeric values.
+var (
+	cronMonthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	cronDayNames = map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}
+)
+
+// rruleDays maps cron day of week numbers to RRULE weekdays. Both 0 and 7
+// are Sunday in cron.
+var rruleDays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA", "SU"}
+
+// cronField describes one of the five cron fields.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: cronMonthNames},
+	{name: "day of week", min: 0, max: 7, names: cronDayNames},
+}
+
+// cronToRRule converts a five field cron expression into an equivalent RFC
+// 5545 recurrence rule.
+func cronToRRule(expr string) (string, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return "", fmt.Errorf("expected %d fields, got %d", len(cronFields), len(fields))
+	}
+
+	values := make([][]int, len(fields))
+	for i, field := range fields {
+		v, err := cronFields[i].parse(field)
+		if err != nil {
+			return "", err
+		}
+		values[i] = v
+	}
+
+	minutes, hours, monthDays, months, weekDays := values[0], values[1], values[2], values[3], values[4]
+
+	// Cron matches either the day of month or the day of week when both are
+	// restricted, while a recurrence rule would require both to match.
+	if monthDays != nil && weekDays != nil {
+		return "", errors.New("restricting both day of month and day of week cannot be expressed as a single recurrence rule")
+	}
+
+	freq := "DAILY"
+	switch {
+	case months != nil:
+		freq = "YEARLY"
+	case monthDays != nil:
+		freq = "MONTHLY"
+	case weekDays != nil:
+		freq = "WEEKLY"
+	}
+
+	parts := []string{"FREQ=" + freq}
+	if months != nil {
+		parts = append(parts, "BYMONTH="+joinInts(months))
+	}
+	if monthDays != nil {
+		parts = append(parts, "BYMONTHDAY="+joinInts(monthDays))
+	}
+	if weekDays != nil {
+		days := make([]string, 0, len(weekDays))
+		seen := make(map[string]bool)
+		for _, d := range weekDays {
+			if !seen[rruleDays[d]] {
+				seen[rruleDays[d]] = true
+				days = append(days, rruleDays[d])
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	parts = append(parts,
+		"BYHOUR="+joinInts(cronFields[1].orAll(hours)),
+		"BYMINUTE="+joinInts(cronFields[0].orAll(minutes)),
+		"BYSECOND=0",
+	)
+
+	return strings.Join(parts, ";"), nil
+}
+
+// parse expands a cron field into the sorted values it matches. A nil slice
+// means the field is unrestricted.
+func (f cronField) parse(field string) ([]int, error) {
+	if field == "*" || field == "?" {
+		return nil, nil
+	}
+
+	matched := make([]bool, f.max+1)
+	for _, item := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid %s step %q", f.name, stepStr)
+			}
+			step = n
+		}
+
+		lo, hi := f.min, f.max
+		if rng != "*" {
+			loStr, hiStr, isRange := strings.Cut(rng, "-")
+
+			var err error
+			if lo, err = f.value(loStr); err != nil {
+				return nil, err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = f.value(hiStr); err != nil {
+					return nil, err
+				}
+			} else if hasStep {
+				hi = f.max
+			}
+			if hi < lo {
+				return nil, fmt.Errorf("invalid %s range %q", f.name, rng)
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			matched[v] = true
+		}
+	}
+
+	values := make([]int, 0, len(matched))
+	for v, ok := range matched {
+		if ok {
+			values = append(values, v)
+		}
+	}
+
+	return values, nil
+}
+
+// value parses a single numeric or named value of the field.
+func (f cronField) value(s string) (int, error) {
+	if n, ok := f.names[strings.ToUpper(s)]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("invalid %s value %q: must be between %d and %d", f.name, s, f.min, f.max)
+	}
+
+	return n, nil
+}
+
+// orAll returns values, or every value of the field if values is nil.
+func (f cronField) orAll(values []int) []int {
+	if values != nil {
+		return values
+	}
+
+	all := make([]int, 0, f.max-f.min+1)
+	for v := f.min; v <= f.max; v++ {
+		all = append(all, v)
+	}
+
+	return all
+}
+
+// joinInts joins values with commas.
+func joinInts(values []int) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.Itoa(v)
+	}
+
+	return strings.Join(s, ",")
+}
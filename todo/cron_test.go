@@ -0,0 +1,94 @@
+package todo
+
+import "testing"
+
+func TestCronToRRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "daily",
+			expr: "30 9 * * *",
+			want: "FREQ=DAILY;BYHOUR=9;BYMINUTE=30;BYSECOND=0",
+		},
+		{
+			name: "weekdays",
+			expr: "0 9 * * 1-5",
+			want: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0;BYSECOND=0",
+		},
+		{
+			name: "day names",
+			expr: "0 9 * * mon,fri",
+			want: "FREQ=WEEKLY;BYDAY=MO,FR;BYHOUR=9;BYMINUTE=0;BYSECOND=0",
+		},
+		{
+			name: "sunday as 0 and 7",
+			expr: "0 0 * * 0,7",
+			want: "FREQ=WEEKLY;BYDAY=SU;BYHOUR=0;BYMINUTE=0;BYSECOND=0",
+		},
+		{
+			name: "monthly",
+			expr: "0 8 1,15 * *",
+			want: "FREQ=MONTHLY;BYMONTHDAY=1,15;BYHOUR=8;BYMINUTE=0;BYSECOND=0",
+		},
+		{
+			name: "yearly with month names",
+			expr: "0 0 1 jan,jul *",
+			want: "FREQ=YEARLY;BYMONTH=1,7;BYMONTHDAY=1;BYHOUR=0;BYMINUTE=0;BYSECOND=0",
+		},
+		{
+			name: "steps",
+			expr: "*/15 9-17/4 * * *",
+			want: "FREQ=DAILY;BYHOUR=9,13,17;BYMINUTE=0,15,30,45;BYSECOND=0",
+		},
+		{
+			name: "step from value",
+			expr: "50/5 0 * * *",
+			want: "FREQ=DAILY;BYHOUR=0;BYMINUTE=50,55;BYSECOND=0",
+		},
+		{
+			name: "macro",
+			expr: "@weekly",
+			want: "FREQ=WEEKLY;BYDAY=SU;BYHOUR=0;BYMINUTE=0;BYSECOND=0",
+		},
+		{
+			name: "macro case and space",
+			expr: " @Daily ",
+			want: "FREQ=DAILY;BYHOUR=0;BYMINUTE=0;BYSECOND=0",
+		},
+		{name: "too few fields", expr: "0 9 * *", wantErr: true},
+		{name: "too many fields", expr: "0 0 9 * * *", wantErr: true},
+		{name: "out of range", expr: "60 9 * * *", wantErr: true},
+		{name: "reversed range", expr: "0 17-9 * * *", wantErr: true},
+		{name: "zero step", expr: "*/0 * * * *", wantErr: true},
+		{name: "unknown name", expr: "0 0 * * funday", wantErr: true},
+		{name: "day of month and day of week", expr: "0 0 1 * 1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cronToRRule(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("cronToRRule(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("cronToRRule(%q) = %q, want %q", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronToRRuleIsValidRRule(t *testing.T) {
+	for _, expr := range []string{"30 9 * * *", "0 9 * * 1-5", "0 8 1,15 * *", "0 0 1 1 *", "@hourly"} {
+		rule, err := cronToRRule(expr)
+		if err != nil {
+			t.Fatalf("cronToRRule(%q) error = %v", expr, err)
+		}
+		if err := validateRRule(rule); err != nil {
+			t.Errorf("validateRRule(%q) error = %v", rule, err)
+		}
+	}
+}
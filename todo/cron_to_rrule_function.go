@@ -0,0 +1,60 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Compile-time assertions that our concrete cronToRRuleFunction implements the
+// Function interface.
+var (
+	_ function.Function = &cronToRRuleFunction{}
+)
+
+// NewCronToRRuleFunction returns our implementation of this function.
+func NewCronToRRuleFunction() function.Function {
+	return &cronToRRuleFunction{}
+}
+
+// cronToRRuleFunction is the concrete type that implements the Function
+// interface.
+type cronToRRuleFunction struct{}
+
+// Metadata returns the function name.
+func (f *cronToRRuleFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cron_to_rrule"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *cronToRRuleFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Convert a cron expression to a recurrence rule",
+		Description: "Converts a five field cron expression, or a macro such as @daily, into an equivalent RFC 5545 recurrence rule. " +
+			"Expressions restricting both the day of month and the day of week are rejected since cron matches either of them.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "cron",
+				Description: "The cron expression to convert, such as \"0 9 * * MON\".",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run converts the cron argument.
+func (f *cronToRRuleFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var expr string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &expr))
+	if resp.Error != nil {
+		return
+	}
+
+	rule, err := cronToRRule(expr)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "invalid cron expression: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, rule))
+}
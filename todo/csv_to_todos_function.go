@@ -0,0 +1,159 @@
+package todo
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Compile-time assertions that our concrete csvToTodosFunction implements the
+// Function interface.
+var (
+	_ function.Function = &csvToTodosFunction{}
+)
+
+// NewCSVToTodosFunction returns our implementation of this function.
+func NewCSVToTodosFunction() function.Function {
+	return &csvToTodosFunction{}
+}
+
+// csvToTodosFunction is the concrete type that implements the Function
+// interface.
+type csvToTodosFunction struct{}
+
+// csvTodoModel maps a single todo returned by the function to a native Go
+// type.
+type csvTodoModel struct {
+	Text     types.String `tfsdk:"text"`
+	Priority types.String `tfsdk:"priority"`
+}
+
+// csvUnsupportedColumns lists the columns of common todo exports for fields
+// the todo API does not have, which are rejected rather than silently
+// dropped.
+var csvUnsupportedColumns = []string{
+	"due_date",
+	"tags",
+}
+
+// Metadata returns the function name.
+func (f *csvToTodosFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "csv_to_todos"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *csvToTodosFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse todos from CSV",
+		Description: "Parses CSV with a header row into a list of todo objects with text and priority attributes. " +
+			"The text column is required, the priority column is optional and its values are normalized. " +
+			"The todo API has no due dates or tags, so due_date and tags columns are rejected, and other unknown columns are ignored.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "csv",
+				Description: "The CSV to parse.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"text":     types.StringType,
+					"priority": types.StringType,
+				},
+			},
+		},
+	}
+}
+
+// Run parses the CSV argument.
+func (f *csvToTodosFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	todos, err := parseCSVTodos(input)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "invalid CSV: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, todos))
+}
+
+// parseCSVTodos parses CSV with a header row into todos.
+func parseCSVTodos(input string) ([]csvTodoModel, error) {
+	r := csv.NewReader(strings.NewReader(input))
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("missing header row")
+		}
+		return nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	if _, ok := columns["text"]; !ok {
+		return nil, errors.New("missing required text column")
+	}
+
+	for _, name := range csvUnsupportedColumns {
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("unsupported %s column: the todo API does not support this field, remove the column", name)
+		}
+	}
+
+	todos := make([]csvTodoModel, 0)
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := r.FieldPos(0)
+		cell := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		td := csvTodoModel{
+			Text:     types.StringValue(cell("text")),
+			Priority: types.StringNull(),
+		}
+
+		if td.Text.ValueString() == "" {
+			return nil, fmt.Errorf("line %d: text must not be empty", line)
+		}
+
+		if priority := cell("priority"); priority != "" {
+			normalized, err := parsePriority(priority)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			td.Priority = types.StringValue(string(normalized))
+		}
+
+		todos = append(todos, td)
+	}
+
+	return todos, nil
+}
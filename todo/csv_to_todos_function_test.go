@@ -0,0 +1,69 @@
+package todo
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseCSVTodos(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []csvTodoModel
+		wantErr bool
+	}{
+		{
+			name:  "text only",
+			input: "text\nBuy milk\nWalk the dog\n",
+			want: []csvTodoModel{
+				{Text: types.StringValue("Buy milk"), Priority: types.StringNull()},
+				{Text: types.StringValue("Walk the dog"), Priority: types.StringNull()},
+			},
+		},
+		{
+			name:  "normalized priority",
+			input: "Text, Priority\nBuy milk, HIGH\nWalk the dog,\n",
+			want: []csvTodoModel{
+				{Text: types.StringValue("Buy milk"), Priority: types.StringValue("high")},
+				{Text: types.StringValue("Walk the dog"), Priority: types.StringNull()},
+			},
+		},
+		{
+			name:  "quoted text and unknown columns",
+			input: "id,text,owner\n1,\"Call Bob, then Alice\",me\n",
+			want: []csvTodoModel{
+				{Text: types.StringValue("Call Bob, then Alice"), Priority: types.StringNull()},
+			},
+		},
+		{
+			name:  "header only",
+			input: "text,priority\n",
+			want:  []csvTodoModel{},
+		},
+		{name: "empty", input: "", wantErr: true},
+		{name: "missing text column", input: "priority\nhigh\n", wantErr: true},
+		{name: "empty text", input: "text,priority\n ,low\n", wantErr: true},
+		{name: "invalid priority", input: "text,priority\nBuy milk,urgent\n", wantErr: true},
+		{name: "due_date column", input: "text,due_date\nBuy milk,2024-01-31\n", wantErr: true},
+		{name: "tags column", input: "text,Tags\nBuy milk,home\n", wantErr: true},
+		{name: "ragged row", input: "text,priority\nBuy milk\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCSVTodos(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCSVTodos() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseCSVTodos() returned %d todos, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if !got[i].Text.Equal(tt.want[i].Text) || !got[i].Priority.Equal(tt.want[i].Priority) {
+					t.Errorf("todo %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	// Embed the IANA time zone database, as the hosts running Terraform may
+	// not have one for time.LoadLocation to read.
+	_ "time/tzdata"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Compile-time assertions that our concrete dueInFunction implements the
+// Function interface.
+var (
+	_ function.Function = &dueInFunction{}
+)
+
+// NewDueInFunction returns our implementation of this function.
+func NewDueInFunction() function.Function {
+	return &dueInFunction{}
+}
+
+// dueInFunction is the concrete type that implements the Function interface.
+type dueInFunction struct{}
+
+// Metadata returns the function name.
+func (f *dueInFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "due_in"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *dueInFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compute a timestamp relative to another",
+		Description: "Returns an RFC3339 timestamp the given duration after a base timestamp. " +
+			"Functions must return the same result for the same arguments, so the base is required: " +
+			"pass plantimestamp() for a timestamp relative to the current run, as in due_in(plantimestamp(), \"72h\"). " +
+			"An optional IANA time zone name such as \"Europe/Berlin\" controls the offset of the result, which defaults to UTC.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "timestamp",
+				Description: "The RFC3339 base timestamp.",
+			},
+			function.StringParameter{
+				Name:        "duration",
+				Description: "A duration string such as \"72h\" or \"30m\".",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:        "timezone",
+			Description: "An optional IANA time zone name for the result.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run computes the timestamp.
+func (f *dueInFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var timestamp, duration string
+	var timezones []string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &timestamp, &duration, &timezones))
+	if resp.Error != nil {
+		return
+	}
+
+	base, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "invalid timestamp: "+err.Error())
+		return
+	}
+
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, "invalid duration: "+err.Error())
+		return
+	}
+
+	if len(timezones) > 1 {
+		resp.Error = function.NewArgumentFuncError(2, "at most one timezone may be given")
+		return
+	}
+
+	location := time.UTC
+	if len(timezones) == 1 {
+		location, err = time.LoadLocation(timezones[0])
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(2, "invalid timezone: "+err.Error())
+			return
+		}
+	}
+
+	due := base.Add(d).In(location)
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, due.Format(time.RFC3339)))
+}
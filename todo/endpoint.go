@@ -0,0 +1,51 @@
+package todo
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// defaultEndpointScheme is the scheme of provider endpoints given without
+// one, such as todo.example.com.
+const defaultEndpointScheme = "https"
+
+// normalizeEndpoint returns the todo API URL for the provider endpoint
+// rawURL, adding the default scheme if it has none and removing any trailing
+// slash, so that equivalent endpoints share one URL.
+func normalizeEndpoint(rawURL string) (string, error) {
+	// Reject surrounding whitespace, or text pasted after the URL, that the
+	// URL parser would otherwise accept as part of the path.
+	if strings.ContainsFunc(rawURL, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) {
+		return "", fmt.Errorf("endpoint %q must not contain whitespace", rawURL)
+	}
+
+	if !strings.Contains(rawURL, "://") {
+		rawURL = defaultEndpointScheme + "://" + rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	switch u.Scheme {
+	case "http", "https", mockScheme:
+	default:
+		return "", fmt.Errorf("endpoint %q must use the http, https, or %s scheme", rawURL, mockScheme)
+	}
+
+	if u.Host == "" {
+		return "", fmt.Errorf("endpoint %q must include a host name", rawURL)
+	}
+
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("endpoint %q must not include a query or fragment", rawURL)
+	}
+
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = strings.TrimRight(u.RawPath, "/")
+
+	return u.String(), nil
+}
@@ -0,0 +1,41 @@
+package todo
+
+import "testing"
+
+func TestNormalizeEndpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		rawURL  string
+		want    string
+		wantErr bool
+	}{
+		{name: "https", rawURL: "https://todo.example.com", want: "https://todo.example.com"},
+		{name: "http with port", rawURL: "http://localhost:8080", want: "http://localhost:8080"},
+		{name: "default scheme", rawURL: "todo.example.com", want: "https://todo.example.com"},
+		{name: "default scheme with port", rawURL: "todo.example.com:8443", want: "https://todo.example.com:8443"},
+		{name: "path", rawURL: "https://example.com/todo", want: "https://example.com/todo"},
+		{name: "trailing slash", rawURL: "https://todo.example.com/", want: "https://todo.example.com"},
+		{name: "path with trailing slash", rawURL: "https://example.com/todo/", want: "https://example.com/todo"},
+		{name: "mock", rawURL: mockScheme + "://local", want: mockScheme + "://local"},
+		{name: "empty", rawURL: "", wantErr: true},
+		{name: "leading space", rawURL: " https://todo.example.com", wantErr: true},
+		{name: "trailing newline", rawURL: "https://todo.example.com\n", wantErr: true},
+		{name: "pasted text", rawURL: "https://todo.example.com is the server", wantErr: true},
+		{name: "unsupported scheme", rawURL: "ftp://todo.example.com", wantErr: true},
+		{name: "missing host", rawURL: "https://", wantErr: true},
+		{name: "query", rawURL: "https://todo.example.com?env=prod", wantErr: true},
+		{name: "fragment", rawURL: "https://todo.example.com#top", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeEndpoint(tt.rawURL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeEndpoint(%q) error = %v, wantErr %v", tt.rawURL, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeEndpoint(%q) = %q, want %q", tt.rawURL, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package todo
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sudomateo/todo/todo"
+)
+
+// managedTodoFields lists the JSON fields of a todo that are managed by
+// first-class attributes and therefore cannot be set through extra_fields.
+var managedTodoFields = []string{"id", "text", "priority", "completed", "time_created", "time_updated"}
+
+// todoPayload is a request body made of params merged with extra fields that
+// the provider does not know about yet.
+type todoPayload struct {
+	params any
+	extra  map[string]json.RawMessage
+}
+
+// MarshalJSON implements json.Marshaler. Fields of params take precedence
+// over extra fields of the same name.
+func (p todoPayload) MarshalJSON() ([]byte, error) {
+	if len(p.extra) == 0 {
+		return json.Marshal(p.params)
+	}
+
+	buf, err := json.Marshal(p.params)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(buf, &fields); err != nil {
+		return nil, err
+	}
+
+	for key, value := range p.extra {
+		if _, ok := fields[key]; !ok {
+			fields[key] = value
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+// rawTodo is a todo decoded from an API response along with the raw response
+// body, which includes any fields the provider does not know about yet.
+type rawTodo struct {
+	todo.Todo
+	Raw json.RawMessage
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *rawTodo) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &t.Todo); err != nil {
+		return err
+	}
+	t.Raw = slices.Clone(data)
+
+	return nil
+}
+
+// parseExtraFields parses a JSON-encoded object of extra todo fields. It
+// returns nil for an empty value.
+func parseExtraFields(value string) (map[string]json.RawMessage, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var extra map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(value), &extra); err != nil {
+		return nil, fmt.Errorf("extra_fields must be a JSON-encoded object: %w", err)
+	}
+
+	for key := range extra {
+		if slices.Contains(managedTodoFields, key) {
+			return nil, fmt.Errorf("extra_fields must not set %q, use the %s attribute instead", key, key)
+		}
+	}
+
+	return extra, nil
+}
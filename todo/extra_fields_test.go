@@ -0,0 +1,99 @@
+package todo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sudomateo/todo/todo"
+)
+
+func TestParseExtraFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		wantKeys []string
+		wantErr  bool
+	}{
+		{name: "empty", value: ""},
+		{name: "blank", value: "  \n"},
+		{name: "empty object", value: "{}"},
+		{name: "fields", value: `{"due_date":"2024-01-31","tags":["home"]}`, wantKeys: []string{"due_date", "tags"}},
+		{name: "nested", value: `{"meta":{"text":"nested fields are not managed"}}`, wantKeys: []string{"meta"}},
+		{name: "array", value: `["due_date"]`, wantErr: true},
+		{name: "string", value: `"due_date"`, wantErr: true},
+		{name: "malformed", value: `{"due_date":`, wantErr: true},
+		{name: "managed text", value: `{"text":"Buy milk"}`, wantErr: true},
+		{name: "managed completed", value: `{"completed":true,"tags":[]}`, wantErr: true},
+		{name: "managed id", value: `{"id":"0b6ff3bd-95a4-4c3e-a6b7-8b8d7a0efb1a"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseExtraFields(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseExtraFields(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if len(got) != len(tt.wantKeys) {
+				t.Fatalf("parseExtraFields(%q) = %v, want keys %v", tt.value, got, tt.wantKeys)
+			}
+			for _, key := range tt.wantKeys {
+				if _, ok := got[key]; !ok {
+					t.Errorf("parseExtraFields(%q) is missing key %q", tt.value, key)
+				}
+			}
+		})
+	}
+}
+
+func TestTodoPayloadMarshalJSON(t *testing.T) {
+	params := todo.TodoCreateParams{Text: "Buy milk", Priority: todo.PriorityLow}
+
+	tests := []struct {
+		name  string
+		extra map[string]json.RawMessage
+		want  string
+	}{
+		{
+			name: "no extra fields",
+			want: `{"text":"Buy milk","priority":"low"}`,
+		},
+		{
+			name:  "extra fields",
+			extra: map[string]json.RawMessage{"due_date": json.RawMessage(`"2024-01-31"`), "tags": json.RawMessage(`["home"]`)},
+			want:  `{"due_date":"2024-01-31","priority":"low","tags":["home"],"text":"Buy milk"}`,
+		},
+		{
+			name:  "params take precedence",
+			extra: map[string]json.RawMessage{"text": json.RawMessage(`"Buy eggs"`)},
+			want:  `{"priority":"low","text":"Buy milk"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(todoPayload{params: params, extra: tt.extra})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("json.Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRawTodoUnmarshalJSON(t *testing.T) {
+	body := `{"id":"0b6ff3bd-95a4-4c3e-a6b7-8b8d7a0efb1a","text":"Buy milk","priority":"high","completed":true,"tags":["home"]}`
+
+	var td rawTodo
+	if err := json.Unmarshal([]byte(body), &td); err != nil {
+		t.Fatal(err)
+	}
+
+	if td.ID.String() != "0b6ff3bd-95a4-4c3e-a6b7-8b8d7a0efb1a" || td.Text != "Buy milk" || td.Priority != todo.PriorityHigh || !td.Completed {
+		t.Errorf("decoded todo = %+v", td.Todo)
+	}
+	if string(td.Raw) != body {
+		t.Errorf("raw body = %s, want %s", td.Raw, body)
+	}
+}
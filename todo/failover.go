@@ -0,0 +1,85 @@
+package todo
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"sync/atomic"
+)
+
+// failoverHosts holds the base URLs of redundant todo API deployments and
+// which of them requests are currently sent to.
+type failoverHosts struct {
+	urls   []*url.URL
+	active atomic.Int64
+}
+
+// newFailoverHosts returns failoverHosts for the base URLs, starting with the
+// first one. It returns nil for fewer than two base URLs.
+func newFailoverHosts(rawURLs []string) (*failoverHosts, error) {
+	if len(rawURLs) < 2 {
+		return nil, nil
+	}
+
+	var f failoverHosts
+	for _, rawURL := range rawURLs {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, err
+		}
+		f.urls = append(f.urls, u)
+	}
+
+	return &f, nil
+}
+
+// current returns the index of the base URL requests are sent to, and u
+// rewritten from the first base URL to that one. It may be called on nil
+// failoverHosts, in which case u is returned as is.
+func (f *failoverHosts) current(u *url.URL) (int, *url.URL) {
+	if f == nil {
+		return 0, u
+	}
+
+	i := int(f.active.Load())
+	if i == 0 {
+		return 0, u
+	}
+
+	rewritten := *f.urls[i]
+	rewritten.Path = strings.TrimSuffix(f.urls[i].Path, "/") + strings.TrimPrefix(u.Path, strings.TrimSuffix(f.urls[0].Path, "/"))
+	rewritten.RawPath = ""
+	rewritten.RawQuery = u.RawQuery
+
+	return i, &rewritten
+}
+
+// failover moves requests from the base URL at index failed to the next one,
+// unless another request already did. It returns the base URL requests are
+// now sent to.
+func (f *failoverHosts) failover(failed int) *url.URL {
+	next := (failed + 1) % len(f.urls)
+	if !f.active.CompareAndSwap(int64(failed), int64(next)) {
+		next = int(f.active.Load())
+	}
+
+	return f.urls[next]
+}
+
+// len returns the number of base URLs. It may be called on nil
+// failoverHosts.
+func (f *failoverHosts) len() int {
+	if f == nil {
+		return 1
+	}
+
+	return len(f.urls)
+}
+
+// isConnectError reports whether err means the request never reached the
+// server, so that sending it to another server cannot apply it twice.
+func isConnectError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,114 @@
+package todo
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sudomateo/todo/todo"
+)
+
+// icsTimeLayout is the UTC date-time format of iCalendar (RFC 5545).
+const icsTimeLayout = "20060102T150405Z"
+
+// renderICS renders todos as an iCalendar object with a VTODO component per
+// todo. Priorities are spread over the iCalendar priority range, where 1 is
+// the highest priority and 9 the lowest, by their position in allowed.
+// Timestamps are taken from the todos so the output is stable between runs.
+func renderICS(name string, todos []todo.Todo, allowed priorities) string {
+	var b strings.Builder
+
+	line := func(content string) {
+		b.WriteString(foldICSLine(content))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//sudomateo//terraform-provider-todo//EN")
+	line("CALSCALE:GREGORIAN")
+	if name != "" {
+		line("X-WR-CALNAME:" + escapeICSText(name))
+	}
+
+	for _, td := range todos {
+		line("BEGIN:VTODO")
+		line("UID:" + td.ID.String())
+		line("DTSTAMP:" + icsTime(td.TimeUpdated))
+		line("CREATED:" + icsTime(td.TimeCreated))
+		line("LAST-MODIFIED:" + icsTime(td.TimeUpdated))
+		line("SUMMARY:" + escapeICSText(td.Text))
+		if priority := icsPriority(td.Priority, allowed); priority != "" {
+			line("PRIORITY:" + priority)
+		}
+		if td.Completed {
+			line("STATUS:COMPLETED")
+		} else {
+			line("STATUS:NEEDS-ACTION")
+		}
+		line("END:VTODO")
+	}
+
+	line("END:VCALENDAR")
+
+	return b.String()
+}
+
+// icsPriority maps priority to an iCalendar priority between 1 (highest) and
+// 9 (lowest) by its position in allowed, or returns an empty string if it is
+// not in allowed.
+func icsPriority(priority todo.Priority, allowed priorities) string {
+	for i, candidate := range allowed {
+		if candidate != priority {
+			continue
+		}
+
+		if len(allowed) == 1 {
+			return "5"
+		}
+
+		// The last allowed priority is the highest one.
+		value := 9 - (8*i)/(len(allowed)-1)
+		return strconv.Itoa(value)
+	}
+
+	return ""
+}
+
+// escapeICSText escapes a TEXT property value.
+func escapeICSText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+// foldICSLine folds a content line longer than 75 octets into continuation
+// lines, without splitting UTF-8 sequences.
+func foldICSLine(content string) string {
+	const limit = 75
+
+	var b strings.Builder
+	width := 0
+	for _, r := range content {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			// The leading space of a continuation line counts towards its
+			// length.
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+
+	return b.String()
+}
+
+// icsTime formats t as an iCalendar UTC date-time.
+func icsTime(t time.Time) string {
+	return t.UTC().Format(icsTimeLayout)
+}
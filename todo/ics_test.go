@@ -0,0 +1,144 @@
+package todo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sudomateo/todo/todo"
+)
+
+func TestICSPriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		priority todo.Priority
+		allowed  priorities
+		want     string
+	}{
+		{name: "low", priority: todo.PriorityLow, allowed: defaultPriorities, want: "9"},
+		{name: "medium", priority: todo.PriorityMedium, allowed: defaultPriorities, want: "5"},
+		{name: "high", priority: todo.PriorityHigh, allowed: defaultPriorities, want: "1"},
+		{name: "subset", priority: todo.PriorityHigh, allowed: priorities{todo.PriorityLow, todo.PriorityHigh}, want: "1"},
+		{name: "single", priority: todo.PriorityLow, allowed: priorities{todo.PriorityLow}, want: "5"},
+		{name: "not allowed", priority: todo.PriorityHigh, allowed: priorities{todo.PriorityLow}, want: ""},
+		{name: "empty", priority: "", allowed: defaultPriorities, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := icsPriority(tt.priority, tt.allowed); got != tt.want {
+				t.Errorf("icsPriority(%q) = %q, want %q", tt.priority, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeICSText(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "Buy milk", want: "Buy milk"},
+		{text: "milk, eggs; bread", want: `milk\, eggs\; bread`},
+		{text: `C:\temp`, want: `C:\\temp`},
+		{text: "line one\nline two", want: `line one\nline two`},
+		{text: "line one\r\nline two", want: `line one\nline two`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := escapeICSText(tt.text); got != tt.want {
+				t.Errorf("escapeICSText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "short", content: "SUMMARY:Buy milk"},
+		{name: "exact", content: "SUMMARY:" + strings.Repeat("a", 67)},
+		{name: "long", content: "SUMMARY:" + strings.Repeat("a", 200)},
+		{name: "multibyte", content: "SUMMARY:" + strings.Repeat("é", 100)},
+		{name: "emoji", content: "SUMMARY:" + strings.Repeat("🥛", 50)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folded := foldICSLine(tt.content)
+
+			for _, line := range strings.Split(folded, "\r\n") {
+				if len(line) > 75 {
+					t.Errorf("line of %d octets is longer than 75: %q", len(line), line)
+				}
+			}
+
+			if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != tt.content {
+				t.Errorf("unfolded line = %q, want %q", unfolded, tt.content)
+			}
+
+			if len(tt.content) <= 75 && folded != tt.content {
+				t.Errorf("short line was folded: %q", folded)
+			}
+		})
+	}
+}
+
+func TestRenderICS(t *testing.T) {
+	created := time.Date(2024, 1, 31, 9, 0, 0, 0, time.FixedZone("CET", 3600))
+	updated := time.Date(2024, 2, 1, 12, 30, 0, 0, time.UTC)
+
+	todos := []todo.Todo{
+		{
+			ID:          uuid.MustParse("0b6ff3bd-95a4-4c3e-a6b7-8b8d7a0efb1a"),
+			Text:        "Buy milk, eggs",
+			Priority:    todo.PriorityHigh,
+			TimeCreated: created,
+			TimeUpdated: updated,
+		},
+		{
+			ID:          uuid.MustParse("6f1f0b2e-3f43-4a5f-9f6d-2a0c1d9e8b7c"),
+			Text:        "Walk the dog",
+			Priority:    todo.PriorityLow,
+			Completed:   true,
+			TimeCreated: created,
+			TimeUpdated: updated,
+		},
+	}
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//sudomateo//terraform-provider-todo//EN",
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:Chores",
+		"BEGIN:VTODO",
+		"UID:0b6ff3bd-95a4-4c3e-a6b7-8b8d7a0efb1a",
+		"DTSTAMP:20240201T123000Z",
+		"CREATED:20240131T080000Z",
+		"LAST-MODIFIED:20240201T123000Z",
+		`SUMMARY:Buy milk\, eggs`,
+		"PRIORITY:1",
+		"STATUS:NEEDS-ACTION",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:6f1f0b2e-3f43-4a5f-9f6d-2a0c1d9e8b7c",
+		"DTSTAMP:20240201T123000Z",
+		"CREATED:20240131T080000Z",
+		"LAST-MODIFIED:20240201T123000Z",
+		"SUMMARY:Walk the dog",
+		"PRIORITY:9",
+		"STATUS:COMPLETED",
+		"END:VTODO",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	if got := renderICS("Chores", todos, defaultPriorities); got != want {
+		t.Errorf("renderICS() =\n%s\nwant\n%s", got, want)
+	}
+}
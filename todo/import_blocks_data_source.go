@@ -0,0 +1,272 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Compile-time assertions that our concrete importBlocksDataSource implements
+// the necessary interfaces for a data source.
+var (
+	_ datasource.DataSource              = &importBlocksDataSource{}
+	_ datasource.DataSourceWithConfigure = &importBlocksDataSource{}
+)
+
+// NewImportBlocksDataSource returns our implementation of this data source.
+func NewImportBlocksDataSource() datasource.DataSource {
+	return &importBlocksDataSource{}
+}
+
+// importBlocksDataSource is the concrete type that implements the DataSource
+// interface.
+type importBlocksDataSource struct {
+	client *apiClient
+}
+
+// importBlocksDataSourceModel maps data source schema data to a native Go
+// type.
+type importBlocksDataSourceModel struct {
+	ID         types.String        `tfsdk:"id"`
+	Query      types.String        `tfsdk:"query"`
+	ExcludeIDs types.Set           `tfsdk:"exclude_ids"`
+	Endpoint   types.String        `tfsdk:"endpoint"`
+	Blocks     []importBlocksModel `tfsdk:"blocks"`
+	Content    types.String        `tfsdk:"content"`
+}
+
+// importBlocksModel maps data source schema data to a native Go type.
+type importBlocksModel struct {
+	TodoID         types.String `tfsdk:"todo_id"`
+	ResourceName   types.String `tfsdk:"resource_name"`
+	ImportBlock    types.String `tfsdk:"import_block"`
+	ResourceConfig types.String `tfsdk:"resource_config"`
+}
+
+// Metadata returns the data source type name.
+func (d *importBlocksDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_import_blocks"
+}
+
+// Schema defines the configuration for the data source block.
+func (d *importBlocksDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Generates import blocks and todo_todo resource configuration for the todos matching query, to bring existing todos under Terraform management. " +
+			"The todo API does not know which todos Terraform already manages, so todos managed by todo_todo resources are included unless listed in exclude_ids.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"query": schema.StringAttribute{
+				Optional: true,
+				Description: "Space separated terms that must all match: is:open, is:completed, priority:<priority>, " +
+					"or any other word to match against the todo text.",
+			},
+			"exclude_ids": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "IDs of todos to leave out, such as the IDs of todos already managed by todo_todo resources, to avoid importing them twice.",
+			},
+			"endpoint": schema.StringAttribute{
+				Optional: true,
+				Description: "URL of the todo API to list todos from, overriding the provider host. " +
+					"The generated import IDs and resource configuration then use the same endpoint.",
+				Validators: []validator.String{
+					endpointValidator{},
+				},
+			},
+			"blocks": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"todo_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"resource_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"import_block": schema.StringAttribute{
+							Computed: true,
+						},
+						"resource_config": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"content": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *importBlocksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state importBlocksDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := parseTodoQuery(state.Query.ValueString(), d.client.allowedPriorities())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("query"),
+			"Invalid todo query",
+			err.Error(),
+		)
+		return
+	}
+
+	exclude := make(map[string]bool)
+	if !state.ExcludeIDs.IsNull() {
+		var ids []string
+		diags = state.ExcludeIDs.ElementsAs(ctx, &ids, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for _, id := range ids {
+			exclude[id] = true
+		}
+	}
+
+	// List the todos of the endpoint, if set, and generate configuration
+	// that manages them there.
+	client := d.client
+	var endpoint string
+	if !state.Endpoint.IsNull() {
+		endpoint, err = normalizeEndpoint(state.Endpoint.ValueString())
+		if err == nil {
+			client, err = d.client.forEndpoint(endpoint)
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("endpoint"),
+				"Invalid todo API endpoint",
+				"The provider cannot create a todo API client for the endpoint: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	todos, err := client.ListTodos(ctx)
+
+	// Warn if the todo API rate limit budget is running low.
+	resp.Diagnostics.Append(client.rateLimitDiagnostics()...)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read todos",
+			err.Error(),
+		)
+		return
+	}
+
+	var content strings.Builder
+	used := make(map[string]bool)
+	for _, td := range todos {
+		if !query.matches(td) || exclude[td.ID.String()] {
+			continue
+		}
+
+		// Derive a unique resource name from the todo text.
+		base := hclIdentifier(td.Text)
+		name := base
+		for i := 2; used[name]; i++ {
+			name = fmt.Sprintf("%s_%d", base, i)
+		}
+		used[name] = true
+
+		importID := td.ID.String()
+		resourceConfig := fmt.Sprintf("resource \"todo_todo\" %q {\n  text     = %s\n  priority = %s\n", name, hclQuote(td.Text), hclQuote(string(td.Priority)))
+		if endpoint != "" {
+			importID = endpoint + "/" + importID
+			resourceConfig += fmt.Sprintf("  endpoint = %s\n", hclQuote(endpoint))
+		}
+		resourceConfig += "}\n"
+
+		importBlock := fmt.Sprintf("import {\n  to = todo_todo.%s\n  id = %s\n}\n", name, hclQuote(importID))
+
+		state.Blocks = append(state.Blocks, importBlocksModel{
+			TodoID:         types.StringValue(td.ID.String()),
+			ResourceName:   types.StringValue(name),
+			ImportBlock:    types.StringValue(importBlock),
+			ResourceConfig: types.StringValue(resourceConfig),
+		})
+
+		if content.Len() > 0 {
+			content.WriteString("\n")
+		}
+		content.WriteString(importBlock + "\n" + resourceConfig)
+	}
+
+	state.Content = types.StringValue(content.String())
+
+	// Set the data source ID to a placeholder value for testing.
+	state.ID = types.StringValue("import_blocks_id_placeholder")
+
+	// Set the state with the values from the read operation.
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *importBlocksDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*apiClient)
+}
+
+// hclIdentifier converts s into a valid HCL identifier made of lowercase
+// letters, digits and underscores.
+func hclIdentifier(s string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			underscore = false
+		case !underscore && b.Len() > 0:
+			b.WriteRune('_')
+			underscore = true
+		}
+	}
+
+	name := strings.TrimSuffix(b.String(), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "todo_" + name
+	}
+
+	return strings.TrimSuffix(name, "_")
+}
+
+// hclQuote returns s as a quoted HCL string, escaping template sequences.
+func hclQuote(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+		"${", "$${",
+		"%{", "%%{",
+	)
+
+	return `"` + r.Replace(s) + `"`
+}
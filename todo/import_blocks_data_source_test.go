@@ -0,0 +1,71 @@
+package todo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sudomateo/todo/todo"
+)
+
+func TestImportBlocksDataSourceRead(t *testing.T) {
+	host, otherHost := newMockName("import-blocks"), newMockName("import-blocks-other")
+
+	now := time.Now()
+	managed := seedMockTodos(t, host, todo.Todo{Text: "Managed", Priority: todo.PriorityLow, TimeCreated: now, TimeUpdated: now})[0]
+	unmanaged := seedMockTodos(t, host, todo.Todo{Text: "Buy milk", Priority: todo.PriorityHigh, TimeCreated: now, TimeUpdated: now})[0]
+	other := seedMockTodos(t, otherHost, todo.Todo{Text: "Walk the dog", Priority: todo.PriorityLow, TimeCreated: now, TimeUpdated: now})[0]
+
+	tests := []struct {
+		name        string
+		config      importBlocksDataSourceModel
+		wantContent string
+	}{
+		{
+			name: "exclude ids",
+			config: importBlocksDataSourceModel{
+				ExcludeIDs: types.SetValueMust(types.StringType, []attr.Value{types.StringValue(managed.ID.String())}),
+			},
+			wantContent: "import {\n  to = todo_todo.buy_milk\n  id = \"" + unmanaged.ID.String() + "\"\n}\n\n" +
+				"resource \"todo_todo\" \"buy_milk\" {\n  text     = \"Buy milk\"\n  priority = \"high\"\n}\n",
+		},
+		{
+			name: "endpoint",
+			config: importBlocksDataSourceModel{
+				ExcludeIDs: types.SetNull(types.StringType),
+				Endpoint:   types.StringValue(mockScheme + "://" + otherHost + "/"),
+			},
+			wantContent: "import {\n  to = todo_todo.walk_the_dog\n  id = \"mock://" + otherHost + "/" + other.ID.String() + "\"\n}\n\n" +
+				"resource \"todo_todo\" \"walk_the_dog\" {\n  text     = \"Walk the dog\"\n  priority = \"low\"\n  endpoint = \"mock://" + otherHost + "\"\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &importBlocksDataSource{client: newMockTestClient(t, host, apiClientOptions{})}
+
+			var schemaResp datasource.SchemaResponse
+			d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+			config := newTestState(t, tfsdk.State{Schema: schemaResp.Schema}, &tt.config)
+
+			resp := datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+			d.Read(context.Background(), datasource.ReadRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Read() diagnostics = %v", resp.Diagnostics)
+			}
+
+			var state importBlocksDataSourceModel
+			if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if got := state.Content.ValueString(); got != tt.wantContent {
+				t.Errorf("content =\n%s\nwant\n%s", got, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package todo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// inflightCallTimeout bounds a shared call of an inflightGroup. The call is
+// detached from the contexts of its callers, so this keeps a call every
+// caller gave up on from running forever.
+const inflightCallTimeout = 5 * time.Minute
+
+// inflightGroup coalesces concurrent calls with the same key into a single
+// call whose result is shared by every caller. It is used to avoid sending
+// identical GET requests when Terraform refreshes many resources in parallel.
+//
+// The shared call runs detached from the cancellation of its callers, so
+// cancelling the caller that started it does not fail the calls waiting on it.
+// A cancelled caller stops waiting and returns the error of its context.
+type inflightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*inflightCall
+}
+
+// inflightCall is a call in progress or completed within an inflightGroup.
+type inflightCall struct {
+	done chan struct{}
+	val  any
+	err  error
+}
+
+// do calls fn and returns its result, unless a call with the same key is
+// already in progress, in which case it waits for that call and returns its
+// result instead. fn is called with a context carrying the values of ctx but
+// not its cancellation, bounded by inflightCallTimeout.
+func (g *inflightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (any, error)) (any, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*inflightCall)
+	}
+	call, ok := g.calls[key]
+	if !ok {
+		call = &inflightCall{done: make(chan struct{})}
+		g.calls[key] = call
+		go g.run(ctx, key, call, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.val, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// run runs the shared call of key and hands its result to the callers.
+func (g *inflightGroup) run(ctx context.Context, key string, call *inflightCall, fn func(ctx context.Context) (any, error)) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), inflightCallTimeout)
+	defer cancel()
+
+	call.val, call.err = fn(ctx)
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	close(call.done)
+}
@@ -0,0 +1,44 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestInflightGroupDetachesSharedCall(t *testing.T) {
+	var g inflightGroup
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fnErr := make(chan error, 1)
+	fn := func(ctx context.Context) (any, error) {
+		close(started)
+		<-release
+
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("shared call has no deadline")
+		}
+		fnErr <- ctx.Err()
+		return nil, ctx.Err()
+	}
+
+	// The caller that started the shared call gives up on it.
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		_, err := g.do(ctx, "key", fn)
+		errc <- err
+	}()
+	<-started
+	cancel()
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled caller error = %v, want %v", err, context.Canceled)
+	}
+
+	// The shared call keeps running for any other callers.
+	close(release)
+	if err := <-fnErr; err != nil {
+		t.Errorf("shared call context error = %v, want nil", err)
+	}
+}
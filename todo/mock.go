@@ -0,0 +1,53 @@
+package todo
+
+import (
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/sudomateo/terraform-provider-todo/internal/mockapi"
+)
+
+// mockScheme is the URL scheme of hosts served by the in-memory mock todo API
+// instead of a real server.
+const mockScheme = "mock"
+
+// newMockHTTPClient returns an HTTP client that serves requests from the
+// in-memory mock todo API for the given host name without any network access.
+func newMockHTTPClient(name string) *http.Client {
+	return &http.Client{
+		Transport: mockTransport{handler: mockapi.NewHandler(mockapi.Store(name))},
+	}
+}
+
+// mockTransport is an http.RoundTripper that serves requests with handler.
+type mockTransport struct {
+	handler http.Handler
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+
+	// Accept compressed request bodies.
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+
+		req = req.Clone(req.Context())
+		req.Body = zr
+	}
+
+	rec := httptest.NewRecorder()
+	t.handler.ServeHTTP(rec, req)
+
+	resp := rec.Result()
+	resp.Request = req
+
+	return resp, nil
+}
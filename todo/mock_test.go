@@ -0,0 +1,83 @@
+package todo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/sudomateo/terraform-provider-todo/internal/mockapi"
+	"github.com/sudomateo/todo/todo"
+)
+
+// newMockName returns a mock host name starting with prefix that is unique to
+// the test run, as mock stores live as long as the test binary, which may run
+// a test several times.
+func newMockName(prefix string) string {
+	return prefix + "-" + uuid.NewString()[:8]
+}
+
+// newMockTestClient returns a client for the in-memory mock todo API at the
+// mock host name, which should come from newMockName.
+func newMockTestClient(t *testing.T, name string, opts apiClientOptions) *apiClient {
+	t.Helper()
+
+	c, err := newAPIClient(mockScheme+"://"+name, newMockHTTPClient(name), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+// seedMockTodos stores todos in the mock todo API at the mock host name as
+// given, including their completion and times, assigning IDs to todos without
+// one. It returns the stored todos.
+func seedMockTodos(t *testing.T, name string, todos ...todo.Todo) []todo.Todo {
+	t.Helper()
+
+	store := mockapi.Store(name)
+	for i := range todos {
+		if todos[i].ID == uuid.Nil {
+			todos[i].ID = uuid.New()
+		}
+		if err := store.Create(context.Background(), todos[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return todos
+}
+
+// mockTodoIDs returns the IDs of the todos in the mock todo API at the mock
+// host name.
+func mockTodoIDs(t *testing.T, name string) map[uuid.UUID]bool {
+	t.Helper()
+
+	todos, err := mockapi.Store(name).Query(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := make(map[uuid.UUID]bool, len(todos))
+	for _, td := range todos {
+		ids[td.ID] = true
+	}
+
+	return ids
+}
+
+// newTestState returns state of schema s holding the values of model, which
+// is also usable as the configuration of schema s.
+func newTestState(t *testing.T, s tfsdk.State, model any) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+	s.Raw = tftypes.NewValue(s.Schema.Type().TerraformType(ctx), nil)
+	if diags := s.Set(ctx, model); diags.HasError() {
+		t.Fatalf("setting state: %v", diags)
+	}
+
+	return s
+}
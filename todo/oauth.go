@@ -0,0 +1,96 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// oauthToken is an access token obtained from an OAuth 2.0 token endpoint.
+type oauthToken struct {
+	AccessToken string
+	TokenType   string
+
+	// Expiry is when the token expires, or the zero time if the token
+	// endpoint did not say.
+	Expiry time.Time
+}
+
+// oauthClientCredentials configures the OAuth 2.0 client credentials grant.
+type oauthClientCredentials struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// fetchToken exchanges the client credentials for an access token at the
+// token endpoint, authenticating the client with HTTP basic authentication
+// as recommended by RFC 6749.
+func (cc oauthClientCredentials) fetchToken(ctx context.Context, httpClient *http.Client) (oauthToken, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(cc.Scopes) > 0 {
+		form.Set("scope", strings.Join(cc.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cc.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return oauthToken{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(cc.ClientID), url.QueryEscape(cc.ClientSecret))
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return oauthToken{}, fmt.Errorf("failed requesting access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return oauthToken{}, fmt.Errorf("failed reading access token response: %w", err)
+	}
+
+	var payload struct {
+		AccessToken      string `json:"access_token"`
+		TokenType        string `json:"token_type"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return oauthToken{}, fmt.Errorf("failed requesting access token: received status code %v", resp.StatusCode)
+	}
+
+	if resp.StatusCode != http.StatusOK || payload.Error != "" {
+		msg := payload.Error
+		if payload.ErrorDescription != "" {
+			msg += ": " + payload.ErrorDescription
+		}
+		return oauthToken{}, fmt.Errorf("failed requesting access token: token endpoint returned %d (%s)", resp.StatusCode, msg)
+	}
+
+	if payload.AccessToken == "" {
+		return oauthToken{}, fmt.Errorf("failed requesting access token: response has no access_token")
+	}
+
+	token := oauthToken{
+		AccessToken: payload.AccessToken,
+		TokenType:   payload.TokenType,
+	}
+	if token.TokenType == "" {
+		token.TokenType = "Bearer"
+	}
+	if payload.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(payload.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,202 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Compile-time assertions that our concrete oauthAccessTokenEphemeralResource
+// implements the necessary interfaces for an ephemeral resource.
+var (
+	_ ephemeral.EphemeralResource              = &oauthAccessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithRenew     = &oauthAccessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &oauthAccessTokenEphemeralResource{}
+)
+
+const (
+	// oauthTokenRenewMargin is how long before the access token expires
+	// Terraform is asked to renew it, to account for latency.
+	oauthTokenRenewMargin = time.Minute
+
+	// privateStateKeyExpiresAt is the private state key holding the expiry
+	// of the access token.
+	privateStateKeyExpiresAt = "expires_at"
+)
+
+// NewOAuthAccessTokenEphemeralResource returns our implementation of this
+// ephemeral resource.
+func NewOAuthAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &oauthAccessTokenEphemeralResource{}
+}
+
+// oauthAccessTokenEphemeralResource is the concrete type that implements the
+// EphemeralResource interface.
+type oauthAccessTokenEphemeralResource struct {
+	client *apiClient
+}
+
+// oauthAccessTokenEphemeralResourceModel maps ephemeral resource schema data
+// to a native Go type.
+type oauthAccessTokenEphemeralResourceModel struct {
+	TokenURL     types.String `tfsdk:"token_url"`
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	Scopes       types.List   `tfsdk:"scopes"`
+	AccessToken  types.String `tfsdk:"access_token"`
+	TokenType    types.String `tfsdk:"token_type"`
+	ExpiresAt    types.String `tfsdk:"expires_at"`
+}
+
+// Metadata returns the ephemeral resource type name.
+func (r *oauthAccessTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oauth_access_token"
+}
+
+// Schema defines the configuration for the ephemeral resource block.
+func (r *oauthAccessTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exchanges OAuth 2.0 client credentials for an access token that can be passed to other providers and provisioners during the run. " +
+			"The token is never stored in the plan or state, and a new token is requested every time the ephemeral resource is opened. " +
+			"Terraform cannot hand a new token to resources that already read access_token, so a token that expires while still in use is not replaced. " +
+			"Instead, a warning is reported shortly before it expires.",
+		Attributes: map[string]schema.Attribute{
+			"token_url": schema.StringAttribute{
+				Required:    true,
+				Description: "URL of the OAuth 2.0 token endpoint.",
+			},
+			"client_id": schema.StringAttribute{
+				Required:    true,
+				Description: "OAuth 2.0 client ID.",
+			},
+			"client_secret": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "OAuth 2.0 client secret.",
+			},
+			"scopes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Scopes to request for the access token. The token endpoint grants its default scopes if none are set.",
+			},
+			"access_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Access token issued by the token endpoint.",
+			},
+			"token_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "Type of the access token, such as Bearer.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "RFC3339 time the access token expires at, or null if the token endpoint did not say.",
+			},
+		},
+	}
+}
+
+// Open requests a new access token.
+func (r *oauthAccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data oauthAccessTokenEphemeralResourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cc := oauthClientCredentials{
+		TokenURL:     data.TokenURL.ValueString(),
+		ClientID:     data.ClientID.ValueString(),
+		ClientSecret: data.ClientSecret.ValueString(),
+	}
+	if !data.Scopes.IsNull() {
+		diags = data.Scopes.ElementsAs(ctx, &cc.Scopes, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	token, err := cc.fetchToken(ctx, r.client.externalHTTPClient())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to obtain OAuth access token",
+			err.Error(),
+		)
+		return
+	}
+
+	data.AccessToken = types.StringValue(token.AccessToken)
+	data.TokenType = types.StringValue(token.TokenType)
+	data.ExpiresAt = types.StringNull()
+	if !token.Expiry.IsZero() {
+		data.ExpiresAt = types.StringValue(token.Expiry.UTC().Format(time.RFC3339))
+	}
+
+	// Ask to be renewed before the token expires, to warn about it.
+	if !token.Expiry.IsZero() {
+		resp.RenewAt = token.Expiry.Add(-oauthTokenRenewMargin)
+
+		value, err := json.Marshal(token.Expiry.UTC())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error storing OAuth access token expiry",
+				"Could not encode expiry: "+err.Error(),
+			)
+			return
+		}
+
+		diags = resp.Private.SetKey(ctx, privateStateKeyExpiresAt, value)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Set the result with the values from the open operation.
+	diags = resp.Result.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Renew warns that the access token is about to expire while it is still in
+// use. Renewing cannot change the access_token already read by other
+// resources, so no new token is requested and no further renewal is asked for.
+func (r *oauthAccessTokenEphemeralResource) Renew(ctx context.Context, req ephemeral.RenewRequest, resp *ephemeral.RenewResponse) {
+	value, diags := req.Private.GetKey(ctx, privateStateKeyExpiresAt)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var expiresAt time.Time
+	if err := json.Unmarshal(value, &expiresAt); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading OAuth access token expiry",
+			"Could not decode expiry: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"OAuth access token expiring",
+		"The OAuth access token expires at "+expiresAt.Format(time.RFC3339)+" while Terraform is still using it, and it cannot be renewed during the run. "+
+			"Requests made with the token after it expires will fail. Split the run, or request tokens with a longer lifetime.",
+	)
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (r *oauthAccessTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, _ *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*apiClient)
+}
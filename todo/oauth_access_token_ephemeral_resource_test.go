@@ -0,0 +1,82 @@
+package todo
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOAuthAccessTokenEphemeralResourceOpen(t *testing.T) {
+	// The token endpoint uses a self-signed certificate, which only the
+	// provider configured HTTP client trusts.
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, ok := r.BasicAuth(); !ok || id != "client" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"token","token_type":"Bearer"}`))
+	}))
+	// Handshakes rejected by the default client are expected.
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	client, err := newAPIClient("https://todo.example.com", server.Client(), apiClientOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		client  *apiClient
+		wantErr bool
+	}{
+		{name: "provider client", client: client},
+		{name: "unconfigured provider", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &oauthAccessTokenEphemeralResource{client: tt.client}
+
+			var schemaResp ephemeral.SchemaResponse
+			r.Schema(context.Background(), ephemeral.SchemaRequest{}, &schemaResp)
+			config := newTestState(t, tfsdk.State{Schema: schemaResp.Schema}, &oauthAccessTokenEphemeralResourceModel{
+				TokenURL:     types.StringValue(server.URL),
+				ClientID:     types.StringValue("client"),
+				ClientSecret: types.StringValue("secret"),
+				Scopes:       types.ListNull(types.StringType),
+			})
+
+			resp := ephemeral.OpenResponse{
+				Result: tfsdk.EphemeralResultData{Schema: config.Schema, Raw: config.Raw},
+			}
+			r.Open(context.Background(), ephemeral.OpenRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}, &resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("Open() diagnostics = %v, wantErr %v", resp.Diagnostics, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var result oauthAccessTokenEphemeralResourceModel
+			if diags := resp.Result.Get(context.Background(), &result); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if result.AccessToken.ValueString() != "token" || result.TokenType.ValueString() != "Bearer" {
+				t.Errorf("result = %+v, want the issued token", result)
+			}
+		})
+	}
+}
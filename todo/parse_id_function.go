@@ -0,0 +1,81 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Compile-time assertions that our concrete parseIDFunction implements the
+// Function interface.
+var (
+	_ function.Function = &parseIDFunction{}
+)
+
+// NewParseIDFunction returns our implementation of this function.
+func NewParseIDFunction() function.Function {
+	return &parseIDFunction{}
+}
+
+// parseIDFunction is the concrete type that implements the Function interface.
+type parseIDFunction struct{}
+
+// parseIDModel maps the function return value to a native Go type.
+type parseIDModel struct {
+	Endpoint types.String `tfsdk:"endpoint"`
+	ID       types.String `tfsdk:"id"`
+}
+
+// Metadata returns the function name.
+func (f *parseIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_id"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *parseIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Split a todo import ID",
+		Description: "Splits an import ID of the todo_todo resource, either a todo ID or of the form \"<endpoint>/<id>\", into an object with endpoint and id attributes. " +
+			"The endpoint is null for import IDs without one, and the todo ID must be a UUID.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The import ID to split.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"endpoint": types.StringType,
+				"id":       types.StringType,
+			},
+		},
+	}
+}
+
+// Run splits the import ID.
+func (f *parseIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var importID string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &importID))
+	if resp.Error != nil {
+		return
+	}
+
+	endpoint, id := splitImportID(importID)
+	if _, err := uuid.Parse(id); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "invalid import ID "+importID+`: expected a todo ID or the form "<endpoint>/<id>": `+err.Error())
+		return
+	}
+
+	result := parseIDModel{
+		Endpoint: types.StringNull(),
+		ID:       types.StringValue(id),
+	}
+	if endpoint != "" {
+		result.Endpoint = types.StringValue(endpoint)
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,73 @@
+package todo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sudomateo/todo/todo"
+)
+
+// priorities is an ordered priority vocabulary, from lowest to highest.
+type priorities []todo.Priority
+
+// defaultPriorities is the priority vocabulary of the todo API, used unless
+// the provider is configured with allowed_priorities, which must be a subset.
+var defaultPriorities = priorities{
+	todo.PriorityLow,
+	todo.PriorityMedium,
+	todo.PriorityHigh,
+}
+
+// parse normalizes value and returns the matching priority of p, ignoring
+// case, or an error if value is not in p.
+func (p priorities) parse(value string) (todo.Priority, error) {
+	trimmed := strings.TrimSpace(value)
+
+	for _, priority := range p {
+		if strings.EqualFold(string(priority), trimmed) {
+			return priority, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid priority %q: must be one of %v", value, p.strings())
+}
+
+// strings returns p as a list of strings.
+func (p priorities) strings() []string {
+	values := make([]string, len(p))
+	for i, priority := range p {
+		values[i] = string(priority)
+	}
+
+	return values
+}
+
+// parsePriorityFilter parses the optional priority filter attribute at path
+// against allowed, returning an empty priority if the attribute is null and
+// adding an error to diags if it is invalid. Priority filters are validated
+// when read rather than by schema validators since the vocabulary comes from
+// the provider configuration.
+func parsePriorityFilter(value types.String, p path.Path, allowed priorities, diags *diag.Diagnostics) todo.Priority {
+	if value.IsNull() || value.IsUnknown() {
+		return ""
+	}
+
+	priority, err := allowed.parse(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(p, "Invalid priority", err.Error())
+		return ""
+	}
+
+	return priority
+}
+
+// parsePriority normalizes value and returns the matching todo priority, or an
+// error if value is not a priority the todo API accepts. It uses the default
+// vocabulary, for callers such as provider-defined functions that cannot
+// access the provider configuration.
+func parsePriority(value string) (todo.Priority, error) {
+	return defaultPriorities.parse(value)
+}
@@ -0,0 +1,68 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/sudomateo/todo/todo"
+)
+
+// Compile-time assertions that our concrete priorityWeightFunction implements
+// the Function interface.
+var (
+	_ function.Function = &priorityWeightFunction{}
+)
+
+// priorityWeights maps each todo priority to a numeric weight that sorts from
+// least to most important.
+var priorityWeights = map[todo.Priority]int64{
+	todo.PriorityLow:    1,
+	todo.PriorityMedium: 2,
+	todo.PriorityHigh:   3,
+}
+
+// NewPriorityWeightFunction returns our implementation of this function.
+func NewPriorityWeightFunction() function.Function {
+	return &priorityWeightFunction{}
+}
+
+// priorityWeightFunction is the concrete type that implements the Function
+// interface.
+type priorityWeightFunction struct{}
+
+// Metadata returns the function name.
+func (f *priorityWeightFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "priority_weight"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *priorityWeightFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Convert a todo priority to a number",
+		Description: "Returns the numeric weight of a todo priority: 1 for low, 2 for medium and 3 for high.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "priority",
+				Description: "The todo priority to convert.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+// Run converts the priority argument to its weight.
+func (f *priorityWeightFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var priority string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &priority))
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := parsePriority(priority)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, priorityWeights[normalized]))
+}
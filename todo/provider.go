@@ -1,24 +1,48 @@
 package todo
 
 import (
+	"cmp"
 	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-
 	"github.com/sudomateo/todo/todo"
 )
 
 // Compile-time assertions that our concrete todoProvider implements the
 // Provider interface.
 var (
-	_ provider.Provider = &todoProvider{}
+	_ provider.Provider                       = &todoProvider{}
+	_ provider.ProviderWithFunctions          = &todoProvider{}
+	_ provider.ProviderWithListResources      = &todoProvider{}
+	_ provider.ProviderWithActions            = &todoProvider{}
+	_ provider.ProviderWithEphemeralResources = &todoProvider{}
+	_ provider.ProviderWithConfigValidators   = &todoProvider{}
+	_ provider.ProviderWithValidateConfig     = &todoProvider{}
 )
 
 // New returns our implementation of this provider.
@@ -27,11 +51,69 @@ func New() provider.Provider {
 }
 
 // todoProvider is the concrete type that implements the Provider interface.
-type todoProvider struct{}
+type todoProvider struct {
+	// stats collects the API call statistics of this provider instance,
+	// across its configurations.
+	stats *apiStats
+}
 
 // todoProviderModel maps provider schema data to a native Go type.
 type todoProviderModel struct {
-	Host types.String `tfsdk:"host"`
+	Endpoint              types.String          `tfsdk:"endpoint"`
+	Hosts                 types.List            `tfsdk:"hosts"`
+	Host                  types.String          `tfsdk:"host"`
+	Compression           types.Bool            `tfsdk:"compression"`
+	APIStatsFile          types.String          `tfsdk:"api_stats_file"`
+	MaxConcurrentRequests types.Int64           `tfsdk:"max_concurrent_requests"`
+	RateLimitWarning      types.Int64           `tfsdk:"rate_limit_warning_threshold"`
+	AllowedPriorities     types.List            `tfsdk:"allowed_priorities"`
+	DefaultPriority       types.String          `tfsdk:"default_priority"`
+	CredentialsExec       *credentialsExecModel `tfsdk:"credentials_exec"`
+	SigningKey            types.String          `tfsdk:"signing_key"`
+	MaxIdleConns          types.Int64           `tfsdk:"max_idle_conns"`
+	MaxConnsPerHost       types.Int64           `tfsdk:"max_conns_per_host"`
+	KeepAlive             types.String          `tfsdk:"keepalive"`
+	Token                 types.String          `tfsdk:"token"`
+	Username              types.String          `tfsdk:"username"`
+	Password              types.String          `tfsdk:"password"`
+	ClientID              types.String          `tfsdk:"client_id"`
+	ClientSecret          types.String          `tfsdk:"client_secret"`
+	TokenURL              types.String          `tfsdk:"token_url"`
+	ClientCertPEM         types.String          `tfsdk:"client_cert_pem"`
+	ClientKeyPEM          types.String          `tfsdk:"client_key_pem"`
+	ClientCertFile        types.String          `tfsdk:"client_cert_file"`
+	ClientKeyFile         types.String          `tfsdk:"client_key_file"`
+	CACertPEM             types.String          `tfsdk:"ca_cert_pem"`
+	CACertFile            types.String          `tfsdk:"ca_cert_file"`
+	Insecure              types.Bool            `tfsdk:"insecure"`
+	RequestTimeout        types.String          `tfsdk:"request_timeout"`
+	MaxRetries            types.Int64           `tfsdk:"max_retries"`
+	RetryMaxWait          types.String          `tfsdk:"retry_max_wait"`
+	IdempotentCreates     types.Bool            `tfsdk:"idempotent_creates"`
+	RequestsPerSecond     types.Float64         `tfsdk:"requests_per_second"`
+	ProxyURL              types.String          `tfsdk:"proxy_url"`
+	UserAgentSuffix       types.String          `tfsdk:"user_agent_suffix"`
+	DefaultHeaders        types.Map             `tfsdk:"default_headers"`
+}
+
+// reservedDefaultHeaders lists the request headers managed by the provider,
+// which default_headers cannot set.
+var reservedDefaultHeaders = []string{
+	"Authorization",
+	"Content-Encoding",
+	"Content-Length",
+	"Content-Type",
+	"Host",
+	"If-None-Match",
+	"If-Unmodified-Since",
+	"User-Agent",
+	idempotencyKeyHeader,
+}
+
+// credentialsExecModel maps the credentials_exec block to a native Go type.
+type credentialsExecModel struct {
+	Command types.List `tfsdk:"command"`
+	Env     types.Map  `tfsdk:"env"`
 }
 
 // Metadata returns the provider type name.
@@ -43,13 +125,312 @@ func (p *todoProvider) Metadata(_ context.Context, _ provider.MetadataRequest, r
 func (p *todoProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
-			"host": schema.StringAttribute{
+			"endpoint": schema.StringAttribute{
 				Optional: true,
+				Description: "URL of the todo API, such as http://localhost:8080. Defaults to the https scheme when given without one. Use mock:// to serve requests from an in-memory mock todo API, which is useful for tests and experimentation. Distinct mock host names, such as mock://a and mock://b, have separate todos. May also be set with the TODO_ENDPOINT environment variable, or in the endpoint field of the todo configuration file at ~/.todo/config.json or the path given by the TODO_CONFIG_FILE environment variable. " +
+					"Credentials are read from the token, username, password, client_id, client_secret, and token_url fields of the configuration file when none are set in the configuration or environment.",
+			},
+			"hosts": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "URLs of redundant deployments of the todo API, as an alternative to endpoint. Requests go to the first URL and fail over to the next one when a server cannot be connected to, " +
+					"staying there until it fails in turn. Requests that reached a server are never sent to another one. The mock:// scheme is not supported.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(endpointValidator{}),
+				},
+			},
+			"host": schema.StringAttribute{
+				Optional:           true,
+				Description:        "Deprecated alias of endpoint. May also be set with the TODO_HOST environment variable.",
+				DeprecationMessage: "Use the endpoint attribute instead. The host attribute will be removed in a future major version.",
+			},
+			"compression": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to gzip request bodies. The todo API must accept gzip encoded requests. Defaults to false.",
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of requests sent to the todo API at once, regardless of Terraform parallelism. Defaults to no limit.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"rate_limit_warning_threshold": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Warn once per run when the todo API reports, through the X-RateLimit-Remaining header, that fewer than this many requests remain in the rate limit window. Set to 0 to disable the warning. Defaults to 10.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"allowed_priorities": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Priorities allowed for todos, a subset of the low, medium, and high priorities the todo API accepts. " +
+					"The todo API does not support other priorities. " +
+					"Restricts the priorities accepted when validating and normalizing priorities, and the first priority is the default for new todos unless default_priority is set. " +
+					"Provider-defined functions cannot read the provider configuration and always accept all priorities.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(defaultPriorities.strings()...)),
+				},
+			},
+			"token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "API token sent as a bearer token to authenticate requests to the todo API. May also be set with the TODO_TOKEN environment variable.",
+			},
+			"username": schema.StringAttribute{
+				Optional:    true,
+				Description: "User name for HTTP basic authentication to the todo API. May also be set with the TODO_USERNAME environment variable.",
+			},
+			"password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password for HTTP basic authentication to the todo API. May also be set with the TODO_PASSWORD environment variable.",
+			},
+			"client_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "OAuth 2.0 client ID used to obtain access tokens for the todo API through the client credentials grant. May also be set with the TODO_CLIENT_ID environment variable.",
+			},
+			"client_secret": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "OAuth 2.0 client secret. May also be set with the TODO_CLIENT_SECRET environment variable.",
+			},
+			"token_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the OAuth 2.0 token endpoint. Access tokens are requested when the provider is configured and refreshed before they expire. May also be set with the TODO_TOKEN_URL environment variable.",
+			},
+			"client_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded client certificate presented to the todo API for mutual TLS. Requires a client key.",
+			},
+			"client_key_pem": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "PEM encoded private key of the client certificate.",
+			},
+			"client_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of a PEM encoded client certificate presented to the todo API for mutual TLS, as an alternative to client_cert_pem. May also be set with the TODO_CLIENT_CERT_FILE environment variable.",
+			},
+			"client_key_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of the PEM encoded private key of the client certificate, as an alternative to client_key_pem. May also be set with the TODO_CLIENT_KEY_FILE environment variable.",
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded CA certificates trusted, in addition to the system CAs, when verifying the certificate of the todo API, for servers using a private or self-signed certificate.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of a file of PEM encoded CA certificates, as an alternative to ca_cert_pem. May also be set with the TODO_CA_CERT_FILE environment variable.",
+			},
+			"insecure": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to skip verifying the certificate of the todo API. Only use this in lab environments, as it allows anyone on the network to intercept requests and credentials. A warning is reported whenever it is enabled. Defaults to false.",
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "Maximum duration of each request to the todo API, including reading the response, such as \"30s\". Requests that are retried get the full timeout for every attempt. Set to \"0s\" to disable the timeout. Defaults to 10s.",
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of times a request to the todo API is retried after a connection error, a 429 response, or a 5xx response, with jittered exponential backoff. Only requests that are safe to repeat are retried. Set to 0 to disable retries. Defaults to 2.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"idempotent_creates": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to send an X-Idempotency-Key header with every create and retry creates after a transient failure, reusing the key. Only enable this when the todo API is known to honor the header: the upstream todo API ignores it, so a retried create can produce a duplicate todo. The key only covers retries of a single request, not a create repeated by a later apply. Defaults to false, which never retries creates.",
+			},
+			"retry_max_wait": schema.StringAttribute{
+				Optional:    true,
+				Description: "Maximum wait between retries, such as \"30s\", which also caps waits requested by the todo API through a Retry-After header. Defaults to 2s.",
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Maximum average number of requests per second sent to the todo API by all resources and data sources together, allowing bursts of up to one second worth of requests. Retries count as requests. Defaults to no limit.",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.001),
+				},
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of an http, https, or socks5 proxy to send requests to the todo API through, such as http://proxy.example.com:3128. Hosts listed in the NO_PROXY environment variable are still reached directly. Defaults to the proxy given by the HTTPS_PROXY and HTTP_PROXY environment variables, if any.",
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Text appended to the User-Agent header of every request to the todo API, such as \"platform-team/1.0\", to attribute traffic.",
+			},
+			"default_headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Headers added to every request to the todo API, such as routing headers required by an API gateway. " +
+					"Headers managed by the provider, such as Authorization, Content-Type, and User-Agent, cannot be set.",
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.NoneOfCaseInsensitive(reservedDefaultHeaders...)),
+				},
+			},
+			"default_priority": schema.StringAttribute{
+				Optional:    true,
+				Description: "Priority of todo_todo resources that omit priority. Must be one of the allowed priorities. Defaults to the first allowed priority, which is low for the built-in priorities.",
+			},
+			"signing_key": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Description: "Secret key for todo API deployments that require signed requests. Every request gets an X-Todo-Timestamp header with the Unix time and an X-Todo-Signature header with the hex encoded HMAC-SHA256 of the timestamp, method, request URI, and body separated by newlines. " +
+					"A request rejected with 401 while the Date of the server differs by more than 30 seconds is signed again with the server time. May also be set with the TODO_SIGNING_KEY environment variable.",
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of idle connections kept open to each todo API host for reuse. Defaults to 100.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_conns_per_host": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of connections, idle or in use, to each todo API host. Requests wait for a free connection beyond it. Defaults to no limit.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"keepalive": schema.StringAttribute{
+				Optional:    true,
+				Description: "Interval of TCP keep-alive probes on connections to the todo API, such as \"15s\". Set to \"0s\" to disable keep-alive probes. Defaults to 30s.",
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"api_stats_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of a JSON file to write a summary of the API calls made through this provider configuration to when the provider shuts down. May also be set with the TODO_API_STATS_FILE environment variable.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"credentials_exec": schema.SingleNestedBlock{
+				Description: "Runs an external command, such as a secret manager CLI, to obtain a bearer token for the todo API, so that secrets need not be put in the configuration. " +
+					"The command prints either the bare token, or a JSON object with a token field and optional token_type and RFC3339 expires_at fields. " +
+					"The command is run when the provider is configured, and again shortly before an expires_at time.",
+				Attributes: map[string]schema.Attribute{
+					"command": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Command to run and its arguments, such as [\"vault\", \"read\", \"-field=token\", \"secret/todo\"]. The command is not run through a shell.",
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+							listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+						},
+					},
+					"env": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Environment variables set for the command in addition to the environment of the provider.",
+					},
+				},
 			},
 		},
 	}
 }
 
+// ConfigValidators returns validators for the provider configuration as a
+// whole.
+func (p *todoProvider) ConfigValidators(_ context.Context) []provider.ConfigValidator {
+	return []provider.ConfigValidator{
+		providervalidator.Conflicting(
+			path.MatchRoot("endpoint"),
+			path.MatchRoot("host"),
+			path.MatchRoot("hosts"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("token"),
+			path.MatchRoot("username"),
+			path.MatchRoot("client_id"),
+			path.MatchRoot("credentials_exec"),
+		),
+		providervalidator.RequiredTogether(
+			path.MatchRoot("username"),
+			path.MatchRoot("password"),
+		),
+		providervalidator.RequiredTogether(
+			path.MatchRoot("client_id"),
+			path.MatchRoot("client_secret"),
+			path.MatchRoot("token_url"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("client_cert_pem"),
+			path.MatchRoot("client_cert_file"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("client_key_pem"),
+			path.MatchRoot("client_key_file"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("ca_cert_pem"),
+			path.MatchRoot("ca_cert_file"),
+		),
+	}
+}
+
+// ValidateConfig validates the todo API endpoint at plan time, so that a
+// malformed URL is reported before anything is applied. When the
+// configuration sets no endpoint, the endpoint from the environment is
+// validated instead, since Configure would use it.
+func (p *todoProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var config todoProviderModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Values that are not known yet are validated by Configure.
+	if config.Endpoint.IsUnknown() || config.Host.IsUnknown() || config.Hosts.IsUnknown() {
+		return
+	}
+
+	name, value := "endpoint", config.Endpoint
+	switch {
+	case !config.Endpoint.IsNull():
+	case !config.Host.IsNull():
+		name, value = "host", config.Host
+	case !config.Hosts.IsNull():
+		// The list elements are validated by the attribute validators.
+		return
+	default:
+		env := cmp.Or(os.Getenv("TODO_ENDPOINT"), os.Getenv("TODO_HOST"))
+		if env == "" {
+			return
+		}
+
+		if _, err := normalizeEndpoint(env); err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid todo API endpoint",
+				"The todo API endpoint from the TODO_ENDPOINT or TODO_HOST environment variable is invalid: "+err.Error(),
+			)
+		}
+		return
+	}
+
+	if _, err := normalizeEndpoint(value.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(name),
+			"Invalid todo API endpoint",
+			"The value "+value.String()+" is not a valid todo API endpoint: "+err.Error(),
+		)
+	}
+}
+
 // Configure creates an API client for the todo API that will be used by
 // resources and data sources.
 func (p *todoProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
@@ -63,13 +444,58 @@ func (p *todoProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
-	// Ensure the host attribute is a known value.
-	if config.Host.IsUnknown() {
+	// Ensure the endpoint attributes are known values.
+	for _, name := range []string{"endpoint", "host"} {
+		var value types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+		if value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Unknown todo API endpoint",
+				"The provider cannot create the todo API client as there is an unknown configuration value for the todo API endpoint. "+
+					"Either target apply the source of the value first, set the value statically in the configuration, or use the TODO_ENDPOINT environment variable.",
+			)
+		}
+	}
+
+	// Ensure the credential attributes are known values.
+	credentials := []struct {
+		name string
+		env  string
+	}{
+		{"token", "TODO_TOKEN"},
+		{"username", "TODO_USERNAME"},
+		{"password", "TODO_PASSWORD"},
+		{"client_id", "TODO_CLIENT_ID"},
+		{"client_secret", "TODO_CLIENT_SECRET"},
+		{"token_url", "TODO_TOKEN_URL"},
+		{"signing_key", "TODO_SIGNING_KEY"},
+		{"client_cert_pem", "TODO_CLIENT_CERT_FILE"},
+		{"client_key_pem", "TODO_CLIENT_KEY_FILE"},
+		{"client_cert_file", "TODO_CLIENT_CERT_FILE"},
+		{"client_key_file", "TODO_CLIENT_KEY_FILE"},
+		{"ca_cert_pem", "TODO_CA_CERT_FILE"},
+		{"ca_cert_file", "TODO_CA_CERT_FILE"},
+	}
+	for _, credential := range credentials {
+		var value types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(credential.name), &value)...)
+		if value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(credential.name),
+				"Unknown todo API credentials",
+				"The provider cannot create the todo API client as there is an unknown configuration value for the "+credential.name+" attribute. "+
+					"Either target apply the source of the value first, set the value statically in the configuration, or use the "+credential.env+" environment variable.",
+			)
+		}
+	}
+
+	if config.Hosts.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("host"),
-			"Unknown todo API host",
-			"The provider cannot create the todo API client as there is an unknown configuration value for the todo API host. "+
-				"Either target apply the source of the value first, set the value statically in the configuration, or use the TODO_HOST environment variable.",
+			path.Root("hosts"),
+			"Unknown todo API endpoint",
+			"The provider cannot create the todo API client as there is an unknown configuration value for the todo API hosts. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
 		)
 	}
 
@@ -78,20 +504,61 @@ func (p *todoProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
-	// Read the host value from the environment, but override it if passed in
-	// the configuration.
-	host := os.Getenv("TODO_HOST")
-	if !config.Host.IsNull() {
-		host = config.Host.ValueString()
+	// Read the configuration file shared with other todo API clients, which
+	// has the lowest precedence of all sources.
+	file, filePath, err := loadConfigFile()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read todo configuration file",
+			"The provider cannot read the todo configuration file, set with the TODO_CONFIG_FILE environment variable or at ~/.todo/config.json: "+err.Error(),
+		)
+		return
+	}
+	if filePath != "" {
+		tflog.Debug(ctx, "Read todo configuration file", map[string]any{"path": filePath})
+	}
+
+	// Read the failover hosts, if configured.
+	var hosts []string
+	if !config.Hosts.IsNull() {
+		diags = config.Hosts.ElementsAs(ctx, &hosts, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	// We don't have a host, add an error.
-	if host == "" {
+	// Read the endpoint value from the environment, but override it if passed
+	// in the configuration. The deprecated host attribute and TODO_HOST
+	// environment variable are still honored so existing configurations keep
+	// working, with the endpoint taking precedence. The configuration file is
+	// used last.
+	endpoint := os.Getenv("TODO_ENDPOINT")
+	switch {
+	case !config.Endpoint.IsNull():
+		endpoint = config.Endpoint.ValueString()
+	case !config.Host.IsNull():
+		endpoint = config.Host.ValueString()
+	case len(hosts) > 0:
+		endpoint = hosts[0]
+	case endpoint == "" && os.Getenv("TODO_HOST") != "":
+		endpoint = os.Getenv("TODO_HOST")
+		resp.Diagnostics.AddWarning(
+			"Deprecated TODO_HOST environment variable",
+			"The TODO_HOST environment variable is deprecated and will be removed in a future major version. "+
+				"Use the TODO_ENDPOINT environment variable instead.",
+		)
+	case endpoint == "":
+		endpoint = file.Endpoint
+	}
+
+	// We don't have an endpoint, add an error.
+	if endpoint == "" {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("host"),
-			"Missing todo API host",
-			"The provider cannot create the todo API client as there is a missing or empty value for the todo API host. "+
-				"Set the host value in the configuration or use the TODO_HOST environment variable. "+
+			path.Root("endpoint"),
+			"Missing todo API endpoint",
+			"The provider cannot create the todo API client as there is a missing or empty value for the todo API endpoint. "+
+				"Set the endpoint value in the configuration, use the TODO_ENDPOINT environment variable, or set it in the todo configuration file. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -101,13 +568,471 @@ func (p *todoProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
+	// Validate the endpoint here as well, since values from the environment
+	// bypass the schema validators.
+	host, err := normalizeEndpoint(endpoint)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoint"),
+			"Invalid todo API endpoint",
+			"The provider cannot create the todo API client as the todo API endpoint is invalid: "+err.Error(),
+		)
+		return
+	}
+
+	var failoverURLs []string
+	if len(hosts) > 1 {
+		for i, h := range hosts {
+			u, err := normalizeEndpoint(h)
+			if err == nil && strings.HasPrefix(u, mockScheme+"://") {
+				err = fmt.Errorf("failing over between %s:// hosts is not supported", mockScheme)
+			}
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("hosts").AtListIndex(i),
+					"Invalid todo API endpoint",
+					"The provider cannot create the todo API client as a todo API host is invalid: "+err.Error(),
+				)
+				return
+			}
+			failoverURLs = append(failoverURLs, u)
+		}
+	}
+
 	// Set fields for loggin.
 	ctx = tflog.SetField(ctx, "todo_host", host)
 
 	tflog.Debug(ctx, "Creating todo client")
 
-	// Create a new todo client using the values from the configuration.
-	client, err := todo.NewClient(host)
+	// Create a new todo client using the values from the configuration. The
+	// client, and its connection pool, is shared by every resource and data
+	// source.
+	httpClient := newHTTPClient()
+
+	// Present a client certificate for mutual TLS, if configured. Files from
+	// the environment are only used when no certificate is configured.
+	certFile, keyFile := config.ClientCertFile.ValueString(), config.ClientKeyFile.ValueString()
+	if config.ClientCertPEM.IsNull() && config.ClientCertFile.IsNull() {
+		certFile = os.Getenv("TODO_CLIENT_CERT_FILE")
+	}
+	if config.ClientKeyPEM.IsNull() && config.ClientKeyFile.IsNull() {
+		keyFile = os.Getenv("TODO_CLIENT_KEY_FILE")
+	}
+
+	cert, err := loadClientCertificate(config.ClientCertPEM.ValueString(), config.ClientKeyPEM.ValueString(), certFile, keyFile)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_cert_pem"),
+			"Invalid todo API client certificate",
+			"The provider cannot create the todo API client as the client certificate for mutual TLS is invalid: "+err.Error(),
+		)
+		return
+	}
+
+	// Trust additional CAs, if configured.
+	caFile := config.CACertFile.ValueString()
+	if config.CACertPEM.IsNull() && config.CACertFile.IsNull() {
+		caFile = os.Getenv("TODO_CA_CERT_FILE")
+	}
+
+	rootCAs, err := loadCACertPool(config.CACertPEM.ValueString(), caFile)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_pem"),
+			"Invalid todo API CA certificates",
+			"The provider cannot create the todo API client as the CA certificates are invalid: "+err.Error(),
+		)
+		return
+	}
+
+	tlsConfig := &tls.Config{
+		RootCAs: rootCAs,
+	}
+	if cert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*cert}
+	}
+
+	// Skip certificate verification only when explicitly asked to, and never
+	// silently.
+	if config.Insecure.ValueBool() {
+		tlsConfig.InsecureSkipVerify = true
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure"),
+			"TLS certificate verification disabled",
+			"The provider does not verify the certificate of the todo API because insecure is enabled. "+
+				"Requests and credentials can be intercepted by anyone on the network. Only use this in lab environments.",
+		)
+	}
+	httpClient.Transport.(*http.Transport).TLSClientConfig = tlsConfig
+
+	// Tune the connection pool, if configured.
+	transport := httpClient.Transport.(*http.Transport)
+	if !config.MaxIdleConns.IsNull() {
+		transport.MaxIdleConns = int(config.MaxIdleConns.ValueInt64())
+		transport.MaxIdleConnsPerHost = int(config.MaxIdleConns.ValueInt64())
+	}
+	if !config.MaxConnsPerHost.IsNull() {
+		transport.MaxConnsPerHost = int(config.MaxConnsPerHost.ValueInt64())
+	}
+	if !config.KeepAlive.IsNull() {
+		keepAlive, err := time.ParseDuration(config.KeepAlive.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("keepalive"),
+				"Invalid todo API keep-alive interval",
+				"The provider cannot create the todo API client as the keep-alive interval is invalid: "+err.Error(),
+			)
+			return
+		}
+
+		// A negative interval disables keep-alive probes.
+		if keepAlive == 0 {
+			keepAlive = -1
+		}
+		transport.DialContext = (&net.Dialer{
+			Timeout:   dialTimeout,
+			KeepAlive: keepAlive,
+		}).DialContext
+	}
+
+	// Send requests through the configured proxy. Otherwise the transport
+	// uses the proxy from the environment.
+	if !config.ProxyURL.IsNull() {
+		proxy, err := proxyFunc(config.ProxyURL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid todo API proxy URL",
+				"The provider cannot create the todo API client as the proxy URL is invalid: "+err.Error(),
+			)
+			return
+		}
+		httpClient.Transport.(*http.Transport).Proxy = proxy
+	}
+
+	if u, err := url.Parse(host); err == nil && u.Scheme == mockScheme {
+		tflog.Warn(ctx, "Using the in-memory mock todo API, no requests are sent to a server")
+		httpClient = newMockHTTPClient(u.Host)
+	}
+
+	// Bound every request to the todo API so that a stuck server fails the
+	// request instead of hanging Terraform.
+	if !config.RequestTimeout.IsNull() {
+		timeout, err := time.ParseDuration(config.RequestTimeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid todo API request timeout",
+				"The provider cannot create the todo API client as the request timeout is invalid: "+err.Error(),
+			)
+			return
+		}
+		httpClient.Timeout = timeout
+	}
+
+	// Record or replay API interactions for deterministic tests when
+	// requested through the environment.
+	if mode := os.Getenv("TODO_VCR_MODE"); mode != "" {
+		base := httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+
+		vcr, err := newVCRTransport(mode, os.Getenv("TODO_VCR_CASSETTE"), base)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to configure todo API record and replay",
+				"The TODO_VCR_MODE and TODO_VCR_CASSETTE environment variables are invalid: "+err.Error(),
+			)
+			return
+		}
+
+		tflog.Warn(ctx, "Recording or replaying todo API interactions", map[string]any{
+			"mode":     mode,
+			"cassette": os.Getenv("TODO_VCR_CASSETTE"),
+		})
+		httpClient.Transport = vcr
+	}
+
+	rateLimitWarningThreshold := int64(defaultRateLimitWarningThreshold)
+	if !config.RateLimitWarning.IsNull() {
+		rateLimitWarningThreshold = config.RateLimitWarning.ValueInt64()
+	}
+
+	// Use the configured priority vocabulary, if any.
+	var allowedPriorities priorities
+	if !config.AllowedPriorities.IsNull() {
+		var values []string
+		diags = config.AllowedPriorities.ElementsAs(ctx, &values, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for _, value := range values {
+			allowedPriorities = append(allowedPriorities, todo.Priority(value))
+		}
+	}
+
+	// Validate the default priority against the vocabulary, requiring the
+	// canonical case since it is sent to the API as is.
+	var defaultPriority todo.Priority
+	if !config.DefaultPriority.IsNull() {
+		vocabulary := allowedPriorities
+		if len(vocabulary) == 0 {
+			vocabulary = defaultPriorities
+		}
+
+		normalized, err := vocabulary.parse(config.DefaultPriority.ValueString())
+		if err == nil && string(normalized) != config.DefaultPriority.ValueString() {
+			err = fmt.Errorf("invalid priority %q: write it as %q", config.DefaultPriority.ValueString(), normalized)
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("default_priority"),
+				"Invalid default priority",
+				err.Error(),
+			)
+			return
+		}
+		defaultPriority = normalized
+	}
+
+	// Read the token from the environment, but override it if passed in the
+	// configuration.
+	token := os.Getenv("TODO_TOKEN")
+	if !config.Token.IsNull() {
+		token = config.Token.ValueString()
+	}
+
+	// Read the basic authentication credentials the same way.
+	username := os.Getenv("TODO_USERNAME")
+	if !config.Username.IsNull() {
+		username = config.Username.ValueString()
+	}
+	password := os.Getenv("TODO_PASSWORD")
+	if !config.Password.IsNull() {
+		password = config.Password.ValueString()
+	}
+
+	// Read the OAuth 2.0 client credentials the same way.
+	clientID := os.Getenv("TODO_CLIENT_ID")
+	if !config.ClientID.IsNull() {
+		clientID = config.ClientID.ValueString()
+	}
+	clientSecret := os.Getenv("TODO_CLIENT_SECRET")
+	if !config.ClientSecret.IsNull() {
+		clientSecret = config.ClientSecret.ValueString()
+	}
+	tokenURL := os.Getenv("TODO_TOKEN_URL")
+	if !config.TokenURL.IsNull() {
+		tokenURL = config.TokenURL.ValueString()
+	}
+
+	// Read the credentials command, if configured.
+	var execCommand []string
+	var execEnv map[string]string
+	if config.CredentialsExec != nil {
+		diags = config.CredentialsExec.Command.ElementsAs(ctx, &execCommand, false)
+		resp.Diagnostics.Append(diags...)
+		if !config.CredentialsExec.Env.IsNull() {
+			diags = config.CredentialsExec.Env.ElementsAs(ctx, &execEnv, false)
+			resp.Diagnostics.Append(diags...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if len(execCommand) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credentials_exec").AtName("command"),
+				"Missing credentials command",
+				"The credentials_exec block requires a command to run.",
+			)
+			return
+		}
+	}
+
+	// Fall back to the credentials of the configuration file only when none
+	// are set otherwise, so that mixing sources cannot produce a conflict.
+	if token == "" && username == "" && password == "" && clientID == "" && clientSecret == "" && tokenURL == "" && len(execCommand) == 0 {
+		token = file.Token
+		username, password = file.Username, file.Password
+		clientID, clientSecret, tokenURL = file.ClientID, file.ClientSecret, file.TokenURL
+	}
+
+	// Values from the environment and configuration file bypass the config
+	// validators, so check the combination of credentials again.
+	methods := 0
+	for _, value := range []string{token, username, clientID} {
+		if value != "" {
+			methods++
+		}
+	}
+	if len(execCommand) > 0 {
+		methods++
+	}
+	if methods > 1 {
+		resp.Diagnostics.AddError(
+			"Conflicting todo API credentials",
+			"The provider cannot create the todo API client as more than one kind of credentials is set. "+
+				"Set only one of token, username and password, client_id, client_secret, and token_url, or credentials_exec, in the configuration or through the environment.",
+		)
+		return
+	}
+	if (username == "") != (password == "") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Incomplete todo API credentials",
+			"The provider cannot create the todo API client as only one of the basic authentication user name and password is set. "+
+				"Set both username and password, in the configuration or through the environment.",
+		)
+		return
+	}
+
+	if (clientID == "") != (clientSecret == "") || (clientID == "") != (tokenURL == "") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_id"),
+			"Incomplete todo API credentials",
+			"The provider cannot create the todo API client as only some of the OAuth 2.0 client credentials are set. "+
+				"Set all of client_id, client_secret, and token_url, in the configuration or through the environment.",
+		)
+		return
+	}
+
+	var auth authenticator
+	switch {
+	case token != "":
+		auth = bearerTokenAuth(token)
+	case username != "":
+		auth = basicAuth{username: username, password: password}
+	case clientID != "":
+		// Token requests go to the identity provider, never to the mock
+		// todo API.
+		tokenHTTPClient := httpClient
+		if u, err := url.Parse(host); err == nil && u.Scheme == mockScheme {
+			tokenHTTPClient = newHTTPClient()
+		}
+
+		oauth := newOAuthAuth(oauthClientCredentials{
+			TokenURL:     tokenURL,
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+		}, tokenHTTPClient)
+
+		// Request the first token now so that bad credentials fail the
+		// provider configuration rather than the first request.
+		if _, err := oauth.currentToken(ctx); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("client_id"),
+				"Unable to obtain todo API access token",
+				"The provider cannot create the todo API client as the OAuth 2.0 client credentials exchange failed: "+err.Error(),
+			)
+			return
+		}
+
+		auth = oauth
+	case len(execCommand) > 0:
+		helper := &execAuth{
+			command: execCommand,
+			env:     execEnv,
+		}
+
+		// Run the command now so that a failing command fails the provider
+		// configuration rather than the first request.
+		if _, err := helper.currentToken(ctx); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credentials_exec"),
+				"Unable to obtain todo API token",
+				"The provider cannot create the todo API client as the credentials command failed: "+err.Error(),
+			)
+			return
+		}
+
+		auth = helper
+	}
+
+	// Retry transient failures as configured.
+	maxRetries := int64(defaultMaxRetries)
+	if !config.MaxRetries.IsNull() {
+		maxRetries = config.MaxRetries.ValueInt64()
+	}
+
+	retryMaxWait := defaultRetryMaxWait
+	if !config.RetryMaxWait.IsNull() {
+		retryMaxWait, err = time.ParseDuration(config.RetryMaxWait.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_max_wait"),
+				"Invalid todo API retry wait",
+				"The provider cannot create the todo API client as the maximum retry wait is invalid: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// Identify the provider, and the team running it if configured, in
+	// every request.
+	userAgent := "terraform-provider-todo"
+	if req.TerraformVersion != "" {
+		userAgent += " Terraform/" + req.TerraformVersion
+	}
+	if suffix := config.UserAgentSuffix.ValueString(); suffix != "" {
+		userAgent += " " + suffix
+	}
+
+	var headers http.Header
+	if !config.DefaultHeaders.IsNull() {
+		var values map[string]string
+		diags = config.DefaultHeaders.ElementsAs(ctx, &values, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		headers = make(http.Header)
+		for key, value := range values {
+			headers.Set(key, value)
+		}
+	}
+
+	// Sign requests, if configured.
+	signingKey := os.Getenv("TODO_SIGNING_KEY")
+	if !config.SigningKey.IsNull() {
+		signingKey = config.SigningKey.ValueString()
+	}
+
+	var signer *requestSigner
+	if signingKey != "" {
+		signer = newRequestSigner(signingKey)
+	}
+
+	var throttle *requestThrottle
+	if !config.RequestsPerSecond.IsNull() {
+		throttle = newRequestThrottle(config.RequestsPerSecond.ValueFloat64())
+	}
+
+	if p.stats == nil {
+		p.stats = newAPIStats()
+	}
+
+	client, err := newAPIClient(host, httpClient, apiClientOptions{
+		compressRequests:          config.Compression.ValueBool(),
+		maxConcurrentRequests:     int(config.MaxConcurrentRequests.ValueInt64()),
+		rateLimitWarningThreshold: rateLimitWarningThreshold,
+		priorities:                allowedPriorities,
+		defaultPriority:           defaultPriority,
+		auth:                      auth,
+		maxRetries:                int(maxRetries),
+		retryMaxWait:              retryMaxWait,
+		idempotentCreates:         config.IdempotentCreates.ValueBool(),
+		throttle:                  throttle,
+		userAgent:                 userAgent,
+		headers:                   headers,
+		failoverURLs:              failoverURLs,
+		signer:                    signer,
+		stats:                     p.stats,
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create todo API client",
@@ -118,10 +1043,21 @@ func (p *todoProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
+	// Report API call statistics to the configured file, overriding the
+	// environment if passed in the configuration.
+	statsFile := os.Getenv("TODO_API_STATS_FILE")
+	if !config.APIStatsFile.IsNull() {
+		statsFile = config.APIStatsFile.ValueString()
+	}
+	p.stats.configure(ctx, statsFile)
+
 	// Make the todo client available to resources and data sources Configure
 	// methods.
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.ListResourceData = client
+	resp.EphemeralResourceData = client
+	resp.ActionData = client
 
 	tflog.Info(ctx, "Configured todo client", map[string]any{"success": true})
 }
@@ -130,6 +1066,12 @@ func (p *todoProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 func (p *todoProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewTodosDataSource,
+		NewTodosCountDataSource,
+		NewTodoChangesDataSource,
+		NewImportBlocksDataSource,
+		NewTodoDuplicatesDataSource,
+		NewTodoCalendarDataSource,
+		NewTodoMetricsDataSource,
 	}
 }
 
@@ -139,3 +1081,41 @@ func (p *todoProvider) Resources(_ context.Context) []func() resource.Resource {
 		NewTodoResource,
 	}
 }
+
+// EphemeralResources defines the ephemeral resources implemented by this
+// provider.
+func (p *todoProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewOAuthAccessTokenEphemeralResource,
+	}
+}
+
+// Functions defines the provider-defined functions implemented by this
+// provider.
+func (p *todoProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewPriorityWeightFunction,
+		NewValidatePriorityFunction,
+		NewDueInFunction,
+		NewParseIDFunction,
+		NewValidateRRuleFunction,
+		NewCronToRRuleFunction,
+		NewBusinessDaysFromFunction,
+		NewCSVToTodosFunction,
+	}
+}
+
+// ListResources defines the list resources implemented by this provider.
+func (p *todoProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewTodosListResource,
+	}
+}
+
+// Actions defines the actions implemented by this provider.
+func (p *todoProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewPurgeCompletedAction,
+		NewBulkCompleteAction,
+	}
+}
@@ -0,0 +1,38 @@
+package todo
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// proxyFunc returns a proxy function for http.Transport sending requests
+// through the proxy at rawURL, except for hosts excluded by the NO_PROXY
+// environment variable.
+func proxyFunc(rawURL string) (func(*http.Request) (*url.URL, error), error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("proxy URL %q must use the http, https, or socks5 scheme", rawURL)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy URL %q must include a host name", rawURL)
+	}
+
+	config := httpproxy.FromEnvironment()
+	config.HTTPProxy = rawURL
+	config.HTTPSProxy = rawURL
+	proxy := config.ProxyFunc()
+
+	return func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}, nil
+}
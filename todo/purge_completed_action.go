@@ -0,0 +1,173 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sudomateo/todo/todo"
+)
+
+// Compile-time assertions that our concrete purgeCompletedAction implements
+// the necessary interfaces for an action.
+var (
+	_ action.Action              = &purgeCompletedAction{}
+	_ action.ActionWithConfigure = &purgeCompletedAction{}
+)
+
+// NewPurgeCompletedAction returns our implementation of this action.
+func NewPurgeCompletedAction() action.Action {
+	return &purgeCompletedAction{}
+}
+
+// purgeCompletedAction is the concrete type that implements the Action
+// interface.
+type purgeCompletedAction struct {
+	client *apiClient
+}
+
+// purgeCompletedActionModel maps action schema data to a native Go type.
+type purgeCompletedActionModel struct {
+	Priority  types.String `tfsdk:"priority"`
+	OlderThan types.String `tfsdk:"older_than"`
+	MaxItems  types.Int64  `tfsdk:"max_items"`
+	DryRun    types.Bool   `tfsdk:"dry_run"`
+}
+
+// Metadata returns the action type name.
+func (a *purgeCompletedAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_purge_completed"
+}
+
+// Schema defines the configuration for the action block.
+func (a *purgeCompletedAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Deletes completed todos that have not been updated within older_than. " +
+			"The todo API does not record completion times or support archiving, so the last update time is used as the age and matching todos are deleted. " +
+			"Todos managed by todo_todo resources are included, and are planned for creation again on the next refresh. " +
+			"Nothing is deleted if more than max_items todos match.",
+		Attributes: map[string]schema.Attribute{
+			"priority": schema.StringAttribute{
+				Optional: true,
+			},
+			"older_than": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"max_items": schema.Int64Attribute{
+				Required: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"dry_run": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to only report the todos that would be deleted, without deleting them. Defaults to false.",
+			},
+		},
+	}
+}
+
+// Invoke deletes the matching completed todos.
+func (a *purgeCompletedAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config purgeCompletedActionModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate the priority filter against the configured vocabulary.
+	priority := parsePriorityFilter(config.Priority, path.Root("priority"), a.client.allowedPriorities(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	olderThan, _ := time.ParseDuration(config.OlderThan.ValueString())
+	cutoff := time.Now().Add(-olderThan)
+
+	todos, err := a.client.ListTodos(ctx)
+
+	// Warn if the todo API rate limit budget is running low.
+	resp.Diagnostics.Append(a.client.rateLimitDiagnostics()...)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read todos",
+			err.Error(),
+		)
+		return
+	}
+
+	matches := make([]todo.Todo, 0)
+	for _, td := range todos {
+		if !td.Completed || td.TimeUpdated.After(cutoff) {
+			continue
+		}
+
+		if priority != "" && td.Priority != priority {
+			continue
+		}
+
+		matches = append(matches, td)
+	}
+
+	// Refuse to delete anything when more todos match than expected.
+	if int64(len(matches)) > config.MaxItems.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_items"),
+			"Too many matching todos",
+			fmt.Sprintf("%d completed todos matched, more than max_items (%d). No todos were deleted. "+
+				"Narrow the filters or raise max_items.", len(matches), config.MaxItems.ValueInt64()),
+		)
+		return
+	}
+
+	if config.DryRun.ValueBool() {
+		for _, td := range matches {
+			resp.SendProgress(action.InvokeProgressEvent{
+				Message: fmt.Sprintf("Would delete completed todo %s (%q)", td.ID, td.Text),
+			})
+		}
+
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Would purge %d completed todos", len(matches)),
+		})
+		return
+	}
+
+	for _, td := range matches {
+		if err := a.client.DeleteTodo(ctx, td.ID.String()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleting todo",
+				"Could not delete todo ID "+td.ID.String()+": "+err.Error(),
+			)
+			return
+		}
+
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Deleted completed todo %s (%q)", td.ID, td.Text),
+		})
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Purged %d completed todos", len(matches)),
+	})
+}
+
+// Configure adds the provider configured client to the action.
+func (a *purgeCompletedAction) Configure(_ context.Context, req action.ConfigureRequest, _ *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	a.client = req.ProviderData.(*apiClient)
+}
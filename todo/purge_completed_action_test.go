@@ -0,0 +1,97 @@
+package todo
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sudomateo/todo/todo"
+)
+
+func TestPurgeCompletedActionInvoke(t *testing.T) {
+	old := time.Now().Add(-48 * time.Hour)
+
+	tests := []struct {
+		name      string
+		config    purgeCompletedActionModel
+		wantErr   bool
+		wantKept  []string
+		wantFinal string
+	}{
+		{
+			name:      "purge",
+			config:    purgeCompletedActionModel{OlderThan: types.StringValue("24h"), MaxItems: types.Int64Value(5)},
+			wantKept:  []string{"open", "recent"},
+			wantFinal: "Purged 2 completed todos",
+		},
+		{
+			name:      "priority",
+			config:    purgeCompletedActionModel{Priority: types.StringValue("high"), OlderThan: types.StringValue("24h"), MaxItems: types.Int64Value(5)},
+			wantKept:  []string{"open", "recent", "old low"},
+			wantFinal: "Purged 1 completed todos",
+		},
+		{
+			name:      "dry run",
+			config:    purgeCompletedActionModel{OlderThan: types.StringValue("24h"), MaxItems: types.Int64Value(5), DryRun: types.BoolValue(true)},
+			wantKept:  []string{"open", "recent", "old low", "old high"},
+			wantFinal: "Would purge 2 completed todos",
+		},
+		{
+			name:     "max items",
+			config:   purgeCompletedActionModel{OlderThan: types.StringValue("24h"), MaxItems: types.Int64Value(1)},
+			wantErr:  true,
+			wantKept: []string{"open", "recent", "old low", "old high"},
+		},
+		{
+			name:      "max items matched exactly",
+			config:    purgeCompletedActionModel{OlderThan: types.StringValue("24h"), MaxItems: types.Int64Value(2)},
+			wantKept:  []string{"open", "recent"},
+			wantFinal: "Purged 2 completed todos",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := newMockName("purge")
+
+			todos := seedMockTodos(t, name,
+				todo.Todo{Text: "open", Priority: todo.PriorityHigh, TimeCreated: old, TimeUpdated: old},
+				todo.Todo{Text: "recent", Priority: todo.PriorityHigh, Completed: true, TimeCreated: old, TimeUpdated: time.Now()},
+				todo.Todo{Text: "old low", Priority: todo.PriorityLow, Completed: true, TimeCreated: old, TimeUpdated: old},
+				todo.Todo{Text: "old high", Priority: todo.PriorityHigh, Completed: true, TimeCreated: old, TimeUpdated: old},
+			)
+
+			a := &purgeCompletedAction{client: newMockTestClient(t, name, apiClientOptions{})}
+
+			var schemaResp action.SchemaResponse
+			a.Schema(context.Background(), action.SchemaRequest{}, &schemaResp)
+			config := newTestState(t, tfsdk.State{Schema: schemaResp.Schema}, &tt.config)
+
+			var progress []string
+			resp := action.InvokeResponse{
+				SendProgress: func(event action.InvokeProgressEvent) {
+					progress = append(progress, event.Message)
+				},
+			}
+			a.Invoke(context.Background(), action.InvokeRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}, &resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("Invoke() diagnostics = %v, wantErr %v", resp.Diagnostics, tt.wantErr)
+			}
+			if !tt.wantErr && progress[len(progress)-1] != tt.wantFinal {
+				t.Errorf("final progress = %q, want %q", progress[len(progress)-1], tt.wantFinal)
+			}
+
+			ids := mockTodoIDs(t, name)
+			for _, td := range todos {
+				if want := slices.Contains(tt.wantKept, td.Text); ids[td.ID] != want {
+					t.Errorf("todo %q exists = %t, want %t", td.Text, ids[td.ID], want)
+				}
+			}
+		})
+	}
+}
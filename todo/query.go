@@ -0,0 +1,80 @@
+package todo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sudomateo/todo/todo"
+)
+
+// todoQuery is a parsed todo search query. The query language is a list of
+// space separated terms that must all match:
+//
+//   - is:open and is:completed match on completion status.
+//   - priority:<priority> matches on priority.
+//   - Any other term matches todos whose text contains it, ignoring case.
+type todoQuery struct {
+	completed *bool
+	priority  todo.Priority
+	text      []string
+}
+
+// parseTodoQuery parses a todo search query, accepting the priorities in
+// allowed.
+func parseTodoQuery(query string, allowed priorities) (todoQuery, error) {
+	var q todoQuery
+
+	for _, term := range strings.Fields(query) {
+		key, value, ok := strings.Cut(term, ":")
+		if !ok {
+			q.text = append(q.text, strings.ToLower(term))
+			continue
+		}
+
+		switch key {
+		case "is":
+			var completed bool
+			switch value {
+			case "open":
+				completed = false
+			case "completed":
+				completed = true
+			default:
+				return todoQuery{}, fmt.Errorf("invalid term %q: is must be open or completed", term)
+			}
+			q.completed = &completed
+
+		case "priority":
+			priority, err := allowed.parse(value)
+			if err != nil {
+				return todoQuery{}, fmt.Errorf("invalid term %q: %w", term, err)
+			}
+			q.priority = priority
+
+		default:
+			return todoQuery{}, fmt.Errorf("invalid term %q: unknown qualifier %s", term, key)
+		}
+	}
+
+	return q, nil
+}
+
+// matches reports whether td matches every term of the query.
+func (q todoQuery) matches(td todo.Todo) bool {
+	if q.completed != nil && td.Completed != *q.completed {
+		return false
+	}
+
+	if q.priority != "" && td.Priority != q.priority {
+		return false
+	}
+
+	text := strings.ToLower(td.Text)
+	for _, t := range q.text {
+		if !strings.Contains(text, t) {
+			return false
+		}
+	}
+
+	return true
+}
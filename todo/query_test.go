@@ -0,0 +1,97 @@
+package todo
+
+import (
+	"testing"
+
+	"github.com/sudomateo/todo/todo"
+)
+
+func TestParseTodoQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		allowed priorities
+		wantErr bool
+	}{
+		{name: "empty", query: ""},
+		{name: "text", query: "rotate certs"},
+		{name: "qualifiers", query: "is:open priority:high certs"},
+		{name: "priority case", query: "priority:HIGH"},
+		{name: "restricted priority", query: "priority:low", allowed: priorities{todo.PriorityLow}},
+		{name: "disallowed priority", query: "priority:high", allowed: priorities{todo.PriorityLow}, wantErr: true},
+		{name: "unknown priority", query: "priority:urgent", wantErr: true},
+		{name: "invalid status", query: "is:done", wantErr: true},
+		{name: "unknown qualifier", query: "tag:migration", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed := tt.allowed
+			if allowed == nil {
+				allowed = defaultPriorities
+			}
+
+			_, err := parseTodoQuery(tt.query, allowed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTodoQuery(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTodoQueryMatches(t *testing.T) {
+	td := todo.Todo{
+		Text:      "Rotate TLS certs",
+		Priority:  todo.PriorityHigh,
+		Completed: false,
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "", want: true},
+		{query: "rotate", want: true},
+		{query: "ROTATE tls", want: true},
+		{query: "rotate keys", want: false},
+		{query: "is:open", want: true},
+		{query: "is:completed", want: false},
+		{query: "priority:high", want: true},
+		{query: "priority:low", want: false},
+		{query: "is:open priority:high certs", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := parseTodoQuery(tt.query, defaultPriorities)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := q.matches(td); got != tt.want {
+				t.Errorf("query %q matches = %t, want %t", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBulkCompleteQuery(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantErr bool
+	}{
+		{query: "is:open priority:low"},
+		{query: "certs"},
+		{query: "is:completed", wantErr: true},
+		{query: "certs is:completed", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := parseBulkCompleteQuery(tt.query, defaultPriorities)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBulkCompleteQuery(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			}
+		})
+	}
+}
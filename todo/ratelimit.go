@@ -0,0 +1,84 @@
+package todo
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// defaultRateLimitWarningThreshold is the remaining rate limit budget below
+// which a warning is reported, unless configured otherwise.
+const defaultRateLimitWarningThreshold = 10
+
+// rateLimitBudget tracks the rate limit budget reported by the todo API
+// through the X-RateLimit-Remaining and X-RateLimit-Reset headers.
+type rateLimitBudget struct {
+	mu        sync.Mutex
+	threshold int64
+	low       bool
+	remaining int64
+	reset     string
+	warned    bool
+}
+
+// observe records the rate limit budget reported by resp, if any.
+func (b *rateLimitBudget) observe(resp *http.Response) {
+	remaining, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Remaining"), 10, 64)
+	if err != nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if remaining >= b.threshold {
+		return
+	}
+
+	b.low = true
+	b.remaining = remaining
+	b.reset = rateLimitReset(resp.Header.Get("X-RateLimit-Reset"))
+}
+
+// diagnostics returns a warning the first time the budget is found to be
+// below the threshold, and nothing afterwards, so that a run is warned once.
+func (b *rateLimitBudget) diagnostics() diag.Diagnostics {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var diags diag.Diagnostics
+	if !b.low || b.warned {
+		return diags
+	}
+	b.warned = true
+
+	detail := fmt.Sprintf("The todo API reported that only %d requests remain in the current rate limit window", b.remaining)
+	if b.reset != "" {
+		detail += ", which resets " + b.reset
+	}
+	detail += ". Further requests may be throttled and fail. Consider reducing parallelism or waiting for the window to reset."
+
+	diags.AddWarning("todo API rate limit nearly exhausted", detail)
+
+	return diags
+}
+
+// rateLimitReset describes an X-RateLimit-Reset value, which APIs send as
+// either seconds until the reset or the Unix time of the reset.
+func rateLimitReset(value string) string {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return ""
+	}
+
+	// Values this large cannot reasonably be a number of seconds to wait.
+	if n > 1_000_000_000 {
+		return "at " + time.Unix(n, 0).UTC().Format(time.RFC3339)
+	}
+
+	return fmt.Sprintf("in %d seconds", n)
+}
@@ -0,0 +1,131 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// defaultReadRetryBackoff is the backoff used between read attempts when
+	// the retry block does not set one.
+	defaultReadRetryBackoff = time.Second
+)
+
+// dataSourceRetryModel maps the data source retry schema data to a native Go
+// type.
+type dataSourceRetryModel struct {
+	Attempts types.Int64  `tfsdk:"attempts"`
+	Backoff  types.String `tfsdk:"backoff"`
+}
+
+// dataSourceRetrySchema returns the schema for the retry and timeout
+// attributes shared by data sources.
+func dataSourceRetrySchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"retry": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"attempts": schema.Int64Attribute{
+					Optional: true,
+					Validators: []validator.Int64{
+						int64validator.AtLeast(1),
+					},
+				},
+				"backoff": schema.StringAttribute{
+					Optional: true,
+					Validators: []validator.String{
+						durationValidator{},
+					},
+				},
+			},
+		},
+		"timeout": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				durationValidator{},
+			},
+		},
+	}
+}
+
+// readRetryPolicy controls how data sources retry failed reads.
+type readRetryPolicy struct {
+	attempts int64
+	backoff  time.Duration
+	timeout  time.Duration
+}
+
+// newReadRetryPolicy builds a readRetryPolicy from data source configuration.
+// The values have already been validated by the schema.
+func newReadRetryPolicy(retry *dataSourceRetryModel, timeout types.String) readRetryPolicy {
+	policy := readRetryPolicy{
+		attempts: 1,
+		backoff:  defaultReadRetryBackoff,
+	}
+
+	if retry != nil {
+		if !retry.Attempts.IsNull() {
+			policy.attempts = retry.Attempts.ValueInt64()
+		}
+		if !retry.Backoff.IsNull() {
+			policy.backoff, _ = time.ParseDuration(retry.Backoff.ValueString())
+		}
+	}
+
+	if !timeout.IsNull() {
+		policy.timeout, _ = time.ParseDuration(timeout.ValueString())
+	}
+
+	return policy
+}
+
+// do calls fn until it succeeds, fails with an error that is not transient,
+// the attempts are exhausted, or the overall timeout elapses. The backoff
+// doubles after every failed attempt. The context passed to fn is cancelled
+// once the timeout elapses. When fn may be retried, the apiClient does not
+// retry requests itself, so that attempts are not multiplied.
+func (p readRetryPolicy) do(ctx context.Context, fn func(context.Context) error) error {
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	if p.attempts > 1 {
+		ctx = withoutTransientRetries(ctx)
+	}
+
+	backoff := p.backoff
+
+	var err error
+	for attempt := int64(1); ; attempt++ {
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+
+		if attempt >= p.attempts || !isTransientError(err) {
+			return err
+		}
+
+		tflog.Warn(ctx, "Read failed, retrying", map[string]any{
+			"attempt": attempt,
+			"backoff": backoff.String(),
+			"error":   err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("giving up after %v attempts: %w", attempt, err)
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
@@ -0,0 +1,180 @@
+package todo
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rruleWeekdays are the weekday values allowed in BYDAY and WKST.
+var rruleWeekdays = map[string]bool{
+	"MO": true, "TU": true, "WE": true, "TH": true, "FR": true, "SA": true, "SU": true,
+}
+
+// rruleByDayRegexp matches a single BYDAY value such as "MO", "1MO" or "-2FR".
+var rruleByDayRegexp = regexp.MustCompile(`^([+-]?\d{1,2})?([A-Z]{2})$`)
+
+// rruleIntRanges lists the numeric BYxxx rule parts, the largest absolute
+// value they accept and whether negative values are allowed.
+var rruleIntRanges = map[string]struct {
+	min, max int
+	negative bool
+}{
+	"BYSECOND":   {0, 60, false},
+	"BYMINUTE":   {0, 59, false},
+	"BYHOUR":     {0, 23, false},
+	"BYMONTHDAY": {1, 31, true},
+	"BYYEARDAY":  {1, 366, true},
+	"BYWEEKNO":   {1, 53, true},
+	"BYMONTH":    {1, 12, false},
+	"BYSETPOS":   {1, 366, true},
+}
+
+// validateRRule validates rule as an RFC 5545 recurrence rule. An optional
+// "RRULE:" prefix is accepted. Like in RFC 5545, rule part names and values
+// are case-insensitive.
+func validateRRule(rule string) error {
+	body := strings.ToUpper(rule)
+	body = strings.TrimPrefix(body, "RRULE:")
+	if body == "" {
+		return errors.New("rule is empty")
+	}
+
+	parts := make(map[string]string)
+	for _, part := range strings.Split(body, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || name == "" || value == "" {
+			return fmt.Errorf("invalid rule part %q: expected NAME=VALUE", part)
+		}
+
+		if _, ok := parts[name]; ok {
+			return fmt.Errorf("rule part %s is given more than once", name)
+		}
+		parts[name] = value
+
+		if err := validateRRulePart(name, value); err != nil {
+			return err
+		}
+	}
+
+	freq, ok := parts["FREQ"]
+	if !ok {
+		return errors.New("rule part FREQ is required")
+	}
+
+	_, count := parts["COUNT"]
+	_, until := parts["UNTIL"]
+	if count && until {
+		return errors.New("rule parts COUNT and UNTIL must not both be given")
+	}
+
+	// BYDAY ordinals such as 1MO only make sense within a month or a year,
+	// and not with BYWEEKNO, which already selects the week. Within a month,
+	// as with FREQ=MONTHLY or FREQ=YEARLY with BYMONTH, a weekday occurs at
+	// most five times.
+	if byDay, ok := parts["BYDAY"]; ok {
+		if ordinal := rruleByDayMaxOrdinal(byDay); ordinal > 0 {
+			if freq != "MONTHLY" && freq != "YEARLY" {
+				return fmt.Errorf("invalid BYDAY %q: ordinals are only allowed with FREQ=MONTHLY or FREQ=YEARLY", byDay)
+			}
+			if _, ok := parts["BYWEEKNO"]; ok {
+				return fmt.Errorf("invalid BYDAY %q: ordinals are not allowed with BYWEEKNO", byDay)
+			}
+			if _, byMonth := parts["BYMONTH"]; (freq == "MONTHLY" || byMonth) && ordinal > 5 {
+				return fmt.Errorf("invalid BYDAY %q: ordinals must be between 1 and 5 within a month", byDay)
+			}
+		}
+	}
+
+	return nil
+}
+
+// rruleByDayMaxOrdinal returns the largest absolute ordinal of the days of a
+// valid BYDAY value, such as 2 for 1MO,-2FR, or 0 if no day has an ordinal.
+func rruleByDayMaxOrdinal(value string) int {
+	var ordinal int
+	for _, day := range strings.Split(value, ",") {
+		m := rruleByDayRegexp.FindStringSubmatch(day)
+		if m == nil || m[1] == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(m[1])
+		if n < 0 {
+			n = -n
+		}
+		ordinal = max(ordinal, n)
+	}
+
+	return ordinal
+}
+
+// validateRRulePart validates a single NAME=VALUE part of a recurrence rule.
+func validateRRulePart(name, value string) error {
+	switch name {
+	case "FREQ":
+		switch value {
+		case "SECONDLY", "MINUTELY", "HOURLY", "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+			return nil
+		}
+		return fmt.Errorf("invalid FREQ %q", value)
+
+	case "UNTIL":
+		for _, layout := range []string{"20060102", "20060102T150405", "20060102T150405Z"} {
+			if _, err := time.Parse(layout, value); err == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid UNTIL %q: expected a date or date-time such as 20240131 or 20240131T090000Z", value)
+
+	case "COUNT", "INTERVAL":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid %s %q: must be a positive integer", name, value)
+		}
+		return nil
+
+	case "WKST":
+		if !rruleWeekdays[value] {
+			return fmt.Errorf("invalid WKST %q", value)
+		}
+		return nil
+
+	case "BYDAY":
+		for _, day := range strings.Split(value, ",") {
+			m := rruleByDayRegexp.FindStringSubmatch(day)
+			if m == nil || !rruleWeekdays[m[2]] {
+				return fmt.Errorf("invalid BYDAY value %q", day)
+			}
+			if m[1] != "" {
+				n, _ := strconv.Atoi(m[1])
+				if n == 0 || n < -53 || n > 53 {
+					return fmt.Errorf("invalid BYDAY value %q: ordinal must be between 1 and 53", day)
+				}
+			}
+		}
+		return nil
+	}
+
+	r, ok := rruleIntRanges[name]
+	if !ok {
+		return fmt.Errorf("unknown rule part %s", name)
+	}
+
+	for _, v := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s value %q: must be an integer", name, v)
+		}
+		if n < 0 && r.negative {
+			n = -n
+		}
+		if n < r.min || n > r.max {
+			return fmt.Errorf("invalid %s value %q: out of range", name, v)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package todo
+
+import "testing"
+
+func TestValidateRRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		wantErr bool
+	}{
+		{name: "weekly", rule: "FREQ=WEEKLY;BYDAY=MO,WE,FR"},
+		{name: "prefix", rule: "RRULE:FREQ=DAILY;INTERVAL=2"},
+		{name: "lowercase", rule: "rrule:freq=weekly;byday=mo;wkst=su"},
+		{name: "mixed case until", rule: "Freq=Daily;Until=20240131t090000z"},
+		{name: "count", rule: "FREQ=DAILY;COUNT=10"},
+		{name: "monthly ordinal", rule: "FREQ=MONTHLY;BYDAY=1MO,-1FR"},
+		{name: "yearly ordinal", rule: "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH"},
+		{name: "monthly last ordinals", rule: "FREQ=MONTHLY;BYDAY=5MO,-5FR"},
+		{name: "yearly week ordinal", rule: "FREQ=YEARLY;BYDAY=20MO"},
+		{name: "negative month day", rule: "FREQ=MONTHLY;BYMONTHDAY=-1"},
+		{name: "weekly week numbers", rule: "FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO"},
+
+		{name: "empty", rule: "", wantErr: true},
+		{name: "prefix only", rule: "RRULE:", wantErr: true},
+		{name: "missing freq", rule: "BYDAY=MO", wantErr: true},
+		{name: "invalid freq", rule: "FREQ=FORTNIGHTLY", wantErr: true},
+		{name: "missing value", rule: "FREQ=", wantErr: true},
+		{name: "missing equals", rule: "FREQ", wantErr: true},
+		{name: "duplicate part", rule: "FREQ=DAILY;FREQ=WEEKLY", wantErr: true},
+		{name: "duplicate part case", rule: "FREQ=DAILY;freq=WEEKLY", wantErr: true},
+		{name: "count and until", rule: "FREQ=DAILY;COUNT=2;UNTIL=20240131", wantErr: true},
+		{name: "zero count", rule: "FREQ=DAILY;COUNT=0", wantErr: true},
+		{name: "invalid until", rule: "FREQ=DAILY;UNTIL=2024-01-31", wantErr: true},
+		{name: "invalid weekday", rule: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{name: "zero ordinal", rule: "FREQ=MONTHLY;BYDAY=0MO", wantErr: true},
+		{name: "ordinal out of range", rule: "FREQ=YEARLY;BYDAY=54MO", wantErr: true},
+		{name: "monthly ordinal out of range", rule: "FREQ=MONTHLY;BYDAY=20MO", wantErr: true},
+		{name: "monthly negative ordinal out of range", rule: "FREQ=MONTHLY;BYDAY=-6FR", wantErr: true},
+		{name: "yearly by month ordinal out of range", rule: "FREQ=YEARLY;BYMONTH=11;BYDAY=6TH", wantErr: true},
+		{name: "weekly ordinal", rule: "FREQ=WEEKLY;BYDAY=1MO", wantErr: true},
+		{name: "daily ordinal", rule: "FREQ=DAILY;BYDAY=-1FR", wantErr: true},
+		{name: "ordinal with week numbers", rule: "FREQ=YEARLY;BYWEEKNO=20;BYDAY=1MO", wantErr: true},
+		{name: "hour out of range", rule: "FREQ=DAILY;BYHOUR=24", wantErr: true},
+		{name: "negative month", rule: "FREQ=YEARLY;BYMONTH=-1", wantErr: true},
+		{name: "unknown part", rule: "FREQ=DAILY;BYFOO=1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRRule(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateRRule(%q) error = %v, wantErr %v", tt.rule, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package todo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// signatureTimestampHeader carries the Unix time, in seconds, a request
+	// was signed at.
+	signatureTimestampHeader = "X-Todo-Timestamp"
+
+	// signatureHeader carries the hex encoded HMAC-SHA256 signature of a
+	// request.
+	signatureHeader = "X-Todo-Signature"
+
+	// signatureSkewThreshold is the clock difference with the server above
+	// which a rejected signature is attributed to clock skew.
+	signatureSkewThreshold = 30 * time.Second
+)
+
+// requestSigner signs requests to the todo API with an HMAC of their
+// timestamp, method, request URI, and body, for deployments that require
+// signed requests. It corrects its clock for the skew to the server clock
+// when the server rejects a stale signature.
+type requestSigner struct {
+	key []byte
+
+	// skew is added to the local clock, in nanoseconds, to estimate the
+	// server clock.
+	skew atomic.Int64
+}
+
+// newRequestSigner returns a requestSigner signing with key.
+func newRequestSigner(key string) *requestSigner {
+	return &requestSigner{key: []byte(key)}
+}
+
+// sign adds the timestamp and signature headers to req, which sends body.
+//
+// The signature is the HMAC-SHA256 of the timestamp, method, request URI and
+// body separated by newlines, where body is the request body as sent, after
+// any compression.
+func (s *requestSigner) sign(req *http.Request, body []byte) {
+	timestamp := strconv.FormatInt(time.Now().Add(time.Duration(s.skew.Load())).Unix(), 10)
+
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(timestamp + "\n" + req.Method + "\n" + req.URL.RequestURI() + "\n"))
+	mac.Write(body)
+
+	req.Header.Set(signatureTimestampHeader, timestamp)
+	req.Header.Set(signatureHeader, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// correctSkew reports whether resp rejected a request because its signature
+// was stale due to clock skew, judging by the Date header of the response,
+// and corrects the clock of s if so, so that the request can be signed and
+// sent again.
+func (s *requestSigner) correctSkew(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return false
+	}
+
+	skew := time.Until(serverTime)
+	if skew.Abs() < signatureSkewThreshold {
+		return false
+	}
+	s.skew.Store(int64(skew))
+
+	return true
+}
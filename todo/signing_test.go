@@ -0,0 +1,127 @@
+package todo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRequestSignerSign(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+	}{
+		{name: "list", method: http.MethodGet, url: "https://todo.example.com/api/todo"},
+		{name: "query", method: http.MethodGet, url: "https://todo.example.com/api/todo?page=2"},
+		{name: "create", method: http.MethodPost, url: "https://todo.example.com/api/todo", body: `{"text":"Buy milk","priority":"low"}`},
+		{name: "delete", method: http.MethodDelete, url: "https://todo.example.com/api/todo/0b6ff3bd-95a4-4c3e-a6b7-8b8d7a0efb1a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			newRequestSigner("secret").sign(req, []byte(tt.body))
+
+			timestamp := req.Header.Get(signatureTimestampHeader)
+			seconds, err := strconv.ParseInt(timestamp, 10, 64)
+			if err != nil {
+				t.Fatalf("invalid timestamp header %q: %v", timestamp, err)
+			}
+			if d := time.Since(time.Unix(seconds, 0)).Abs(); d > time.Minute {
+				t.Errorf("timestamp is %v away from now", d)
+			}
+
+			mac := hmac.New(sha256.New, []byte("secret"))
+			mac.Write([]byte(timestamp + "\n" + tt.method + "\n" + req.URL.RequestURI() + "\n" + tt.body))
+			want := hex.EncodeToString(mac.Sum(nil))
+
+			if got := req.Header.Get(signatureHeader); got != want {
+				t.Errorf("signature = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestRequestSignerSignatureDependsOnKeyAndBody(t *testing.T) {
+	sign := func(key string, body string) string {
+		req, err := http.NewRequest(http.MethodPost, "https://todo.example.com/api/todo", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		newRequestSigner(key).sign(req, []byte(body))
+		return req.Header.Get(signatureHeader)
+	}
+
+	if sign("a", "body") == sign("b", "body") {
+		t.Error("signatures with different keys are equal")
+	}
+	if sign("a", "body") == sign("a", "other") {
+		t.Error("signatures of different bodies are equal")
+	}
+}
+
+func TestRequestSignerCorrectSkew(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		date       string
+		want       bool
+	}{
+		{name: "server ahead", statusCode: http.StatusUnauthorized, date: time.Now().Add(time.Hour).Format(http.TimeFormat), want: true},
+		{name: "server behind", statusCode: http.StatusUnauthorized, date: time.Now().Add(-time.Hour).Format(http.TimeFormat), want: true},
+		{name: "small skew", statusCode: http.StatusUnauthorized, date: time.Now().Add(5 * time.Second).Format(http.TimeFormat)},
+		{name: "missing date", statusCode: http.StatusUnauthorized},
+		{name: "invalid date", statusCode: http.StatusUnauthorized, date: "yesterday"},
+		{name: "not unauthorized", statusCode: http.StatusForbidden, date: time.Now().Add(time.Hour).Format(http.TimeFormat)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newRequestSigner("secret")
+
+			resp := &http.Response{StatusCode: tt.statusCode, Header: make(http.Header)}
+			if tt.date != "" {
+				resp.Header.Set("Date", tt.date)
+			}
+
+			if got := s.correctSkew(resp); got != tt.want {
+				t.Fatalf("correctSkew() = %t, want %t", got, tt.want)
+			}
+			if !tt.want {
+				if s.skew.Load() != 0 {
+					t.Errorf("skew = %v, want 0", time.Duration(s.skew.Load()))
+				}
+				return
+			}
+
+			// Requests signed after the correction carry the server time.
+			req, err := http.NewRequest(http.MethodGet, "https://todo.example.com/api/todo", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.sign(req, nil)
+
+			seconds, err := strconv.ParseInt(req.Header.Get(signatureTimestampHeader), 10, 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			serverTime, err := http.ParseTime(tt.date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := time.Unix(seconds, 0).Sub(serverTime).Abs(); d > 5*time.Second {
+				t.Errorf("signed timestamp is %v away from the server time", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// providerStats holds the API call statistics of every provider instance
+// of this process, so that the load each provider configuration put on the
+// todo API can be summarized when the provider shuts down.
+var providerStats struct {
+	mu    sync.Mutex
+	stats []*apiStats
+}
+
+// newAPIStats returns empty API call statistics that are summarized by
+// ReportStats.
+func newAPIStats() *apiStats {
+	s := &apiStats{
+		operations: make(map[string]*apiOperationStats),
+	}
+
+	providerStats.mu.Lock()
+	providerStats.stats = append(providerStats.stats, s)
+	providerStats.mu.Unlock()
+
+	return s
+}
+
+// apiStats aggregates API call statistics per operation.
+type apiStats struct {
+	mu         sync.Mutex
+	operations map[string]*apiOperationStats
+
+	// logCtx is the context used to log the summary. Logging requires a
+	// context carrying the provider logger, which only exists while serving
+	// requests, so the context of the last provider configuration is kept.
+	logCtx context.Context
+
+	// file is the path the summary is written to as JSON, if set.
+	file string
+}
+
+// apiOperationStats holds the statistics of a single operation.
+type apiOperationStats struct {
+	Requests    int           `json:"requests"`
+	Errors      int           `json:"errors"`
+	Retries     int           `json:"retries"`
+	RateLimited int           `json:"rate_limited"`
+	TotalTime   time.Duration `json:"total_time_ns"`
+	MaxTime     time.Duration `json:"max_time_ns"`
+}
+
+// configure sets where the summary is reported.
+func (s *apiStats) configure(ctx context.Context, file string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.logCtx = ctx
+	if file != "" {
+		s.file = file
+	}
+}
+
+// record records a single attempt of operation. Attempts after the first are
+// counted as retries.
+func (s *apiStats) record(operation string, attempt int, elapsed time.Duration, resp *http.Response, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	op, ok := s.operations[operation]
+	if !ok {
+		op = &apiOperationStats{}
+		s.operations[operation] = op
+	}
+
+	op.Requests++
+	if attempt > 1 {
+		op.Retries++
+	}
+	if err != nil || resp.StatusCode >= http.StatusBadRequest {
+		op.Errors++
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		op.RateLimited++
+	}
+
+	op.TotalTime += elapsed
+	if elapsed > op.MaxTime {
+		op.MaxTime = elapsed
+	}
+}
+
+// ReportStats logs a summary of the API calls made by each provider instance
+// of this process and writes it to the JSON file configured for the instance,
+// if any. It is meant to be called once the provider server has stopped.
+func ReportStats() {
+	providerStats.mu.Lock()
+	defer providerStats.mu.Unlock()
+
+	for _, s := range providerStats.stats {
+		s.report()
+	}
+}
+
+// report logs the summary of s and writes it to its file, if set.
+func (s *apiStats) report() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.operations) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(s.operations))
+	for name := range s.operations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if s.logCtx != nil {
+		for _, name := range names {
+			op := s.operations[name]
+			tflog.Info(s.logCtx, "todo API call summary", map[string]any{
+				"operation":    name,
+				"requests":     op.Requests,
+				"errors":       op.Errors,
+				"retries":      op.Retries,
+				"rate_limited": op.RateLimited,
+				"avg_time":     (op.TotalTime / time.Duration(op.Requests)).String(),
+				"max_time":     op.MaxTime.String(),
+			})
+		}
+	}
+
+	if s.file != "" {
+		// The summary reveals which operations ran against the API, so it
+		// is only readable by the user running Terraform.
+		buf, err := json.MarshalIndent(s.operations, "", "  ")
+		if err == nil {
+			err = os.WriteFile(s.file, buf, 0o600)
+		}
+		if err != nil && s.logCtx != nil {
+			tflog.Warn(s.logCtx, "Unable to write todo API call summary", map[string]any{
+				"file":  s.file,
+				"error": err.Error(),
+			})
+		}
+	}
+}
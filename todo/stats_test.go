@@ -0,0 +1,76 @@
+package todo
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAPIStatsReport(t *testing.T) {
+	dir := t.TempDir()
+
+	a, b := newAPIStats(), newAPIStats()
+	a.configure(t.Context(), filepath.Join(dir, "a.json"))
+	b.configure(t.Context(), filepath.Join(dir, "b.json"))
+
+	a.record("listing todos", 1, time.Second, &http.Response{StatusCode: http.StatusOK}, nil)
+	a.record("listing todos", 2, 3*time.Second, &http.Response{StatusCode: http.StatusTooManyRequests}, nil)
+	b.record("creating todo", 1, time.Second, &http.Response{StatusCode: http.StatusCreated}, nil)
+
+	a.report()
+	b.report()
+
+	tests := []struct {
+		file string
+		want map[string]apiOperationStats
+	}{
+		{
+			file: "a.json",
+			want: map[string]apiOperationStats{
+				"listing todos": {Requests: 2, Errors: 1, Retries: 1, RateLimited: 1, TotalTime: 4 * time.Second, MaxTime: 3 * time.Second},
+			},
+		},
+		{
+			file: "b.json",
+			want: map[string]apiOperationStats{
+				"creating todo": {Requests: 1, TotalTime: time.Second, MaxTime: time.Second},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			name := filepath.Join(dir, tt.file)
+
+			info, err := os.Stat(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if mode := info.Mode().Perm(); mode != 0o600 {
+				t.Errorf("file mode = %v, want 0600", mode)
+			}
+
+			buf, err := os.ReadFile(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got map[string]apiOperationStats
+			if err := json.Unmarshal(buf, &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("operations = %v, want %v", got, tt.want)
+			}
+			for name, want := range tt.want {
+				if got[name] != want {
+					t.Errorf("operation %q = %+v, want %+v", name, got[name], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package todo
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// requestThrottle is a token bucket limiting the rate of requests to the todo
+// API. It allows bursts of up to one second worth of requests.
+type requestThrottle struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// newRequestThrottle returns a requestThrottle allowing requestsPerSecond
+// requests per second on average.
+func newRequestThrottle(requestsPerSecond float64) *requestThrottle {
+	burst := math.Max(1, math.Floor(requestsPerSecond))
+
+	return &requestThrottle{
+		rate:   requestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a request may be sent or ctx is done. Waiting callers are
+// served in the order they called wait.
+func (t *requestThrottle) wait(ctx context.Context) error {
+	t.mu.Lock()
+	now := time.Now()
+	t.tokens = math.Min(t.burst, t.tokens+now.Sub(t.last).Seconds()*t.rate)
+	t.last = now
+
+	// Take a token, going into debt if there is none, so that later callers
+	// queue up behind this one.
+	t.tokens--
+	if t.tokens >= 0 {
+		t.mu.Unlock()
+		return nil
+	}
+	delay := time.Duration(-t.tokens / t.rate * float64(time.Second))
+	t.mu.Unlock()
+
+	return sleepWithContext(ctx, delay)
+}
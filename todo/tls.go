@@ -0,0 +1,74 @@
+package todo
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// loadClientCertificate returns the client certificate for mutual TLS given
+// either as PEM encoded data or as paths of PEM encoded files. It returns nil
+// if no certificate is configured.
+func loadClientCertificate(certPEM, keyPEM, certFile, keyFile string) (*tls.Certificate, error) {
+	if certFile != "" {
+		buf, err := os.ReadFile(certFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading client certificate: %w", err)
+		}
+		certPEM = string(buf)
+	}
+
+	if keyFile != "" {
+		buf, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading client key: %w", err)
+		}
+		keyPEM = string(buf)
+	}
+
+	switch {
+	case certPEM == "" && keyPEM == "":
+		return nil, nil
+	case certPEM == "":
+		return nil, fmt.Errorf("a client key is set without a client certificate")
+	case keyPEM == "":
+		return nil, fmt.Errorf("a client certificate is set without a client key")
+	}
+
+	cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("parsing client certificate and key: %w", err)
+	}
+
+	return &cert, nil
+}
+
+// loadCACertPool returns the system certificate pool extended with the CA
+// certificates given either as PEM encoded data or as the path of a PEM
+// encoded file. It returns nil if no CA certificates are configured, to use
+// the system pool as is.
+func loadCACertPool(caPEM, caFile string) (*x509.CertPool, error) {
+	if caFile != "" {
+		buf, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA certificates: %w", err)
+		}
+		caPEM = string(buf)
+	}
+
+	if caPEM == "" {
+		return nil, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM([]byte(caPEM)) {
+		return nil, fmt.Errorf("no PEM encoded certificates found in the CA certificates")
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,145 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sudomateo/todo/todo"
+)
+
+// Compile-time assertions that our concrete todoCalendarDataSource implements
+// the necessary interfaces for a data source.
+var (
+	_ datasource.DataSource              = &todoCalendarDataSource{}
+	_ datasource.DataSourceWithConfigure = &todoCalendarDataSource{}
+)
+
+// NewTodoCalendarDataSource returns our implementation of this data source.
+func NewTodoCalendarDataSource() datasource.DataSource {
+	return &todoCalendarDataSource{}
+}
+
+// todoCalendarDataSource is the concrete type that implements the DataSource
+// interface.
+type todoCalendarDataSource struct {
+	client *apiClient
+}
+
+// todoCalendarDataSourceModel maps data source schema data to a native Go
+// type.
+type todoCalendarDataSourceModel struct {
+	ID      types.String          `tfsdk:"id"`
+	Query   types.String          `tfsdk:"query"`
+	Name    types.String          `tfsdk:"name"`
+	Count   types.Int64           `tfsdk:"count"`
+	ICS     types.String          `tfsdk:"ics"`
+	Retry   *dataSourceRetryModel `tfsdk:"retry"`
+	Timeout types.String          `tfsdk:"timeout"`
+}
+
+// Metadata returns the data source type name.
+func (d *todoCalendarDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_calendar"
+}
+
+// Schema defines the configuration for the data source block.
+func (d *todoCalendarDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Renders the todos matching a query as an iCalendar (ICS) document with one VTODO per todo.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"query": schema.StringAttribute{
+				Optional: true,
+				Description: "Space separated terms that must all match: is:open, is:completed, priority:<priority>, " +
+					"or any other word to match against the todo text.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Calendar name shown by calendar applications.",
+			},
+			"count": schema.Int64Attribute{
+				Computed: true,
+			},
+			"ics": schema.StringAttribute{
+				Computed:    true,
+				Description: "The iCalendar document.",
+			},
+		},
+	}
+
+	for name, attribute := range dataSourceRetrySchema() {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoCalendarDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state todoCalendarDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := parseTodoQuery(state.Query.ValueString(), d.client.allowedPriorities())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("query"),
+			"Invalid todo query",
+			err.Error(),
+		)
+		return
+	}
+
+	var todos []todo.Todo
+	err = newReadRetryPolicy(state.Retry, state.Timeout).do(ctx, func(ctx context.Context) error {
+		var err error
+		todos, err = d.client.ListTodos(ctx)
+		return err
+	})
+
+	// Warn if the todo API rate limit budget is running low.
+	resp.Diagnostics.Append(d.client.rateLimitDiagnostics()...)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read todos",
+			err.Error(),
+		)
+		return
+	}
+
+	matches := make([]todo.Todo, 0)
+	for _, td := range todos {
+		if query.matches(td) {
+			matches = append(matches, td)
+		}
+	}
+
+	state.Count = types.Int64Value(int64(len(matches)))
+	state.ICS = types.StringValue(renderICS(state.Name.ValueString(), matches, d.client.allowedPriorities()))
+
+	// Set the data source ID to a placeholder value for testing.
+	state.ID = types.StringValue("todo_calendar_id_placeholder")
+
+	// Set the state with the values from the read operation.
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todoCalendarDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*apiClient)
+}
@@ -0,0 +1,178 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sudomateo/todo/todo"
+)
+
+// Compile-time assertions that our concrete todoChangesDataSource implements
+// the necessary interfaces for a data source.
+var (
+	_ datasource.DataSource              = &todoChangesDataSource{}
+	_ datasource.DataSourceWithConfigure = &todoChangesDataSource{}
+)
+
+// NewTodoChangesDataSource returns our implementation of this data source.
+func NewTodoChangesDataSource() datasource.DataSource {
+	return &todoChangesDataSource{}
+}
+
+// todoChangesDataSource is the concrete type that implements the DataSource
+// interface.
+type todoChangesDataSource struct {
+	client *apiClient
+}
+
+// todoChangesDataSourceModel maps data source schema data to a native Go
+// type.
+type todoChangesDataSourceModel struct {
+	ID      types.String          `tfsdk:"id"`
+	Since   types.String          `tfsdk:"since"`
+	Created []todosModel          `tfsdk:"created"`
+	Updated []todosModel          `tfsdk:"updated"`
+	Retry   *dataSourceRetryModel `tfsdk:"retry"`
+	Timeout types.String          `tfsdk:"timeout"`
+}
+
+// Metadata returns the data source type name.
+func (d *todoChangesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_changes"
+}
+
+// Schema defines the configuration for the data source block.
+func (d *todoChangesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	todoObject := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"text": schema.StringAttribute{
+				Computed: true,
+			},
+			"priority": schema.StringAttribute{
+				Computed: true,
+			},
+			"completed": schema.BoolAttribute{
+				Computed: true,
+			},
+			"time_created": schema.StringAttribute{
+				Computed: true,
+			},
+			"time_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Lists the todos created or updated since a point in time. " +
+			"The todo API does not keep deleted todos, so todos deleted since then are not reported.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"since": schema.StringAttribute{
+				Description: "RFC 3339 timestamp to report changes after.",
+				Required:    true,
+			},
+			"created": schema.ListNestedAttribute{
+				Description:  "Todos created after since.",
+				Computed:     true,
+				NestedObject: todoObject,
+			},
+			"updated": schema.ListNestedAttribute{
+				Description:  "Todos created before and updated after since.",
+				Computed:     true,
+				NestedObject: todoObject,
+			},
+		},
+	}
+
+	for name, attribute := range dataSourceRetrySchema() {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoChangesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state todoChangesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	since, err := time.Parse(time.RFC3339, state.Since.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("since"),
+			"Invalid since timestamp",
+			"The since value must be an RFC3339 timestamp: "+err.Error(),
+		)
+		return
+	}
+
+	var todos []todo.Todo
+	err = newReadRetryPolicy(state.Retry, state.Timeout).do(ctx, func(ctx context.Context) error {
+		var err error
+		todos, err = d.client.ListTodos(ctx)
+		return err
+	})
+
+	// Warn if the todo API rate limit budget is running low.
+	resp.Diagnostics.Append(d.client.rateLimitDiagnostics()...)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read todos",
+			err.Error(),
+		)
+		return
+	}
+
+	// Split the todos into those created after the timestamp and those that
+	// already existed but were updated after it. The todo API does not keep
+	// track of deleted todos, so deletions cannot be reported.
+	for _, todo := range todos {
+		todostate := todosModel{
+			ID:          types.StringValue(todo.ID.String()),
+			Text:        types.StringValue(todo.Text),
+			Priority:    types.StringValue(string(todo.Priority)),
+			Completed:   types.BoolValue(todo.Completed),
+			TimeCreated: types.StringValue(todo.TimeCreated.String()),
+			TimeUpdated: types.StringValue(todo.TimeUpdated.String()),
+		}
+
+		switch {
+		case todo.TimeCreated.After(since):
+			state.Created = append(state.Created, todostate)
+		case todo.TimeUpdated.After(since):
+			state.Updated = append(state.Updated, todostate)
+		}
+	}
+
+	// Set the data source ID to a placeholder value for testing.
+	state.ID = types.StringValue("todo_changes_id_placeholder")
+
+	// Set the state with the values from the read operation.
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todoChangesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*apiClient)
+}
@@ -0,0 +1,318 @@
+package todo
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sudomateo/todo/todo"
+)
+
+// Compile-time assertions that our concrete todoDuplicatesDataSource
+// implements the necessary interfaces for a data source.
+var (
+	_ datasource.DataSource              = &todoDuplicatesDataSource{}
+	_ datasource.DataSourceWithConfigure = &todoDuplicatesDataSource{}
+)
+
+// defaultDuplicateSimilarity is the similarity above which two todos are
+// considered fuzzy duplicates, unless configured otherwise.
+const defaultDuplicateSimilarity = 0.85
+
+// NewTodoDuplicatesDataSource returns our implementation of this data source.
+func NewTodoDuplicatesDataSource() datasource.DataSource {
+	return &todoDuplicatesDataSource{}
+}
+
+// todoDuplicatesDataSource is the concrete type that implements the
+// DataSource interface.
+type todoDuplicatesDataSource struct {
+	client *apiClient
+}
+
+// todoDuplicatesDataSourceModel maps data source schema data to a native Go
+// type.
+type todoDuplicatesDataSourceModel struct {
+	ID         types.String            `tfsdk:"id"`
+	Fuzzy      types.Bool              `tfsdk:"fuzzy"`
+	Similarity types.Float64           `tfsdk:"similarity"`
+	Clusters   []todoDuplicatesCluster `tfsdk:"clusters"`
+	Retry      *dataSourceRetryModel   `tfsdk:"retry"`
+	Timeout    types.String            `tfsdk:"timeout"`
+}
+
+// todoDuplicatesCluster maps a cluster of probable duplicates to a native Go
+// type.
+type todoDuplicatesCluster struct {
+	Key     types.String   `tfsdk:"key"`
+	TodoIDs []types.String `tfsdk:"todo_ids"`
+	Todos   []todosModel   `tfsdk:"todos"`
+}
+
+// Metadata returns the data source type name.
+func (d *todoDuplicatesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_duplicates"
+}
+
+// Schema defines the configuration for the data source block.
+func (d *todoDuplicatesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	todoObject := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"text": schema.StringAttribute{
+				Computed: true,
+			},
+			"priority": schema.StringAttribute{
+				Computed: true,
+			},
+			"completed": schema.BoolAttribute{
+				Computed: true,
+			},
+			"time_created": schema.StringAttribute{
+				Computed: true,
+			},
+			"time_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Groups todos whose text is the same after normalizing case, punctuation, and whitespace into clusters of probable duplicates.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"fuzzy": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Also cluster todos whose normalized text is similar rather than equal. Compares every pair of todos, so it is slow for large numbers of todos. Defaults to false.",
+			},
+			"similarity": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Minimum similarity, between 0 and 1, of the normalized text of two todos to be fuzzy duplicates. Defaults to 0.85.",
+				Validators: []validator.Float64{
+					float64validator.Between(0, 1),
+				},
+			},
+			"clusters": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Computed: true,
+						},
+						"todo_ids": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"todos": schema.ListNestedAttribute{
+							Computed:     true,
+							NestedObject: todoObject,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, attribute := range dataSourceRetrySchema() {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoDuplicatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state todoDuplicatesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var todos []todo.Todo
+	err := newReadRetryPolicy(state.Retry, state.Timeout).do(ctx, func(ctx context.Context) error {
+		var err error
+		todos, err = d.client.ListTodos(ctx)
+		return err
+	})
+
+	// Warn if the todo API rate limit budget is running low.
+	resp.Diagnostics.Append(d.client.rateLimitDiagnostics()...)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read todos",
+			err.Error(),
+		)
+		return
+	}
+
+	similarity := defaultDuplicateSimilarity
+	if !state.Similarity.IsNull() {
+		similarity = state.Similarity.ValueFloat64()
+	}
+
+	// Map the clusters of duplicates to the schema.
+	state.Clusters = make([]todoDuplicatesCluster, 0)
+	for _, cluster := range clusterDuplicates(todos, state.Fuzzy.ValueBool(), similarity) {
+		c := todoDuplicatesCluster{
+			Key: types.StringValue(normalizeTodoText(cluster[0].Text)),
+		}
+
+		for _, todo := range cluster {
+			c.TodoIDs = append(c.TodoIDs, types.StringValue(todo.ID.String()))
+			c.Todos = append(c.Todos, todosModel{
+				ID:          types.StringValue(todo.ID.String()),
+				Text:        types.StringValue(todo.Text),
+				Priority:    types.StringValue(string(todo.Priority)),
+				Completed:   types.BoolValue(todo.Completed),
+				TimeCreated: types.StringValue(todo.TimeCreated.String()),
+				TimeUpdated: types.StringValue(todo.TimeUpdated.String()),
+			})
+		}
+
+		state.Clusters = append(state.Clusters, c)
+	}
+
+	// Set the data source ID to a placeholder value for testing.
+	state.ID = types.StringValue("todo_duplicates_id_placeholder")
+
+	// Set the state with the values from the read operation.
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todoDuplicatesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*apiClient)
+}
+
+// clusterDuplicates groups todos with equal normalized text, or with similar
+// normalized text if fuzzy is set, into clusters of at least two todos. The
+// clusters and the todos within them keep the order of todos.
+func clusterDuplicates(todos []todo.Todo, fuzzy bool, similarity float64) [][]todo.Todo {
+	keys := make([]string, len(todos))
+	for i, td := range todos {
+		keys[i] = normalizeTodoText(td.Text)
+	}
+
+	// Link duplicates with a union-find over the todo indices, where each
+	// cluster is represented by its first todo.
+	parent := make([]int, len(todos))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	union := func(i, j int) {
+		ri, rj := find(i), find(j)
+		switch {
+		case ri < rj:
+			parent[rj] = ri
+		case rj < ri:
+			parent[ri] = rj
+		}
+	}
+
+	first := make(map[string]int)
+	for i, key := range keys {
+		if j, ok := first[key]; ok {
+			union(j, i)
+			continue
+		}
+		first[key] = i
+	}
+
+	if fuzzy {
+		for i := range keys {
+			for j := i + 1; j < len(keys); j++ {
+				if find(i) != find(j) && textSimilarity(keys[i], keys[j]) >= similarity {
+					union(i, j)
+				}
+			}
+		}
+	}
+
+	members := make(map[int][]todo.Todo)
+	var roots []int
+	for i, td := range todos {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], td)
+	}
+
+	clusters := make([][]todo.Todo, 0)
+	for _, root := range roots {
+		if len(members[root]) > 1 {
+			clusters = append(clusters, members[root])
+		}
+	}
+
+	return clusters
+}
+
+// normalizeTodoText lowercases text, treats punctuation as whitespace, and
+// collapses runs of whitespace, so that todos differing only in those are
+// considered equal.
+func normalizeTodoText(text string) string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	return strings.Join(fields, " ")
+}
+
+// textSimilarity returns the similarity of a and b between 0 and 1, based on
+// their Levenshtein distance relative to the longer of the two.
+func textSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+
+	// Compute the edit distance keeping only the previous row.
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
@@ -0,0 +1,291 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sudomateo/todo/todo"
+)
+
+// Compile-time assertions that our concrete todoMetricsDataSource implements
+// the necessary interfaces for a data source.
+var (
+	_ datasource.DataSource              = &todoMetricsDataSource{}
+	_ datasource.DataSourceWithConfigure = &todoMetricsDataSource{}
+)
+
+const (
+	// metricsBucketDay buckets todos by calendar day in UTC.
+	metricsBucketDay = "day"
+
+	// metricsBucketWeek buckets todos by ISO week, starting on Monday, in
+	// UTC.
+	metricsBucketWeek = "week"
+)
+
+// NewTodoMetricsDataSource returns our implementation of this data source.
+func NewTodoMetricsDataSource() datasource.DataSource {
+	return &todoMetricsDataSource{}
+}
+
+// todoMetricsDataSource is the concrete type that implements the DataSource
+// interface.
+type todoMetricsDataSource struct {
+	client *apiClient
+}
+
+// todoMetricsDataSourceModel maps data source schema data to a native Go
+// type.
+type todoMetricsDataSourceModel struct {
+	ID        types.String          `tfsdk:"id"`
+	Since     types.String          `tfsdk:"since"`
+	Until     types.String          `tfsdk:"until"`
+	Bucket    types.String          `tfsdk:"bucket"`
+	Created   types.Int64           `tfsdk:"created"`
+	Completed types.Int64           `tfsdk:"completed"`
+	Buckets   []todoMetricsBucket   `tfsdk:"buckets"`
+	Retry     *dataSourceRetryModel `tfsdk:"retry"`
+	Timeout   types.String          `tfsdk:"timeout"`
+}
+
+// todoMetricsBucket maps the counts of a single time bucket to a native Go
+// type.
+type todoMetricsBucket struct {
+	Start     types.String `tfsdk:"start"`
+	End       types.String `tfsdk:"end"`
+	Created   types.Int64  `tfsdk:"created"`
+	Completed types.Int64  `tfsdk:"completed"`
+}
+
+// Metadata returns the data source type name.
+func (d *todoMetricsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_metrics"
+}
+
+// Schema defines the configuration for the data source block.
+func (d *todoMetricsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Counts the todos created and completed in each day or week of a time window. " +
+			"The todo API has no metrics endpoint, so the counts are computed by the provider from the list of todos. " +
+			"The API does not record when a todo was completed, so a completed todo is counted in the bucket of its last update.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"since": schema.StringAttribute{
+				Required:    true,
+				Description: "RFC3339 timestamp of the start of the window. It is rounded down to the start of its bucket.",
+			},
+			"until": schema.StringAttribute{
+				Optional:    true,
+				Description: "RFC3339 timestamp of the end of the window. Defaults to the current time.",
+			},
+			"bucket": schema.StringAttribute{
+				Optional:    true,
+				Description: "Size of the time buckets, either day or week. Days and weeks are in UTC and weeks start on Monday. Defaults to day.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(metricsBucketDay, metricsBucketWeek),
+				},
+			},
+			"created": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of todos created in the window.",
+			},
+			"completed": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of todos completed in the window.",
+			},
+			"buckets": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"start": schema.StringAttribute{
+							Computed: true,
+						},
+						"end": schema.StringAttribute{
+							Computed: true,
+						},
+						"created": schema.Int64Attribute{
+							Computed: true,
+						},
+						"completed": schema.Int64Attribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, attribute := range dataSourceRetrySchema() {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoMetricsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state todoMetricsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	since, err := time.Parse(time.RFC3339, state.Since.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("since"),
+			"Invalid since timestamp",
+			"The since value must be an RFC3339 timestamp: "+err.Error(),
+		)
+		return
+	}
+
+	until := time.Now()
+	if !state.Until.IsNull() {
+		until, err = time.Parse(time.RFC3339, state.Until.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("until"),
+				"Invalid until timestamp",
+				"The until value must be an RFC3339 timestamp: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if !until.After(since) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("until"),
+			"Invalid until timestamp",
+			"The until value must be after the since value.",
+		)
+		return
+	}
+
+	bucket := metricsBucketDay
+	if !state.Bucket.IsNull() {
+		bucket = state.Bucket.ValueString()
+	}
+
+	var todos []todo.Todo
+	err = newReadRetryPolicy(state.Retry, state.Timeout).do(ctx, func(ctx context.Context) error {
+		var err error
+		todos, err = d.client.ListTodos(ctx)
+		return err
+	})
+
+	// Warn if the todo API rate limit budget is running low.
+	resp.Diagnostics.Append(d.client.rateLimitDiagnostics()...)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read todos",
+			err.Error(),
+		)
+		return
+	}
+
+	var created, completed int64
+	state.Buckets = make([]todoMetricsBucket, 0)
+	for _, b := range bucketTodoMetrics(todos, since, until, bucket) {
+		created += b.created
+		completed += b.completed
+
+		state.Buckets = append(state.Buckets, todoMetricsBucket{
+			Start:     types.StringValue(b.start.Format(time.RFC3339)),
+			End:       types.StringValue(b.end.Format(time.RFC3339)),
+			Created:   types.Int64Value(b.created),
+			Completed: types.Int64Value(b.completed),
+		})
+	}
+
+	state.Created = types.Int64Value(created)
+	state.Completed = types.Int64Value(completed)
+
+	// Set the data source ID to a placeholder value for testing.
+	state.ID = types.StringValue("todo_metrics_id_placeholder")
+
+	// Set the state with the values from the read operation.
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todoMetricsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*apiClient)
+}
+
+// todoMetrics holds the counts of todos in the time bucket [start, end).
+type todoMetrics struct {
+	start     time.Time
+	end       time.Time
+	created   int64
+	completed int64
+}
+
+// bucketTodoMetrics counts the todos created and completed in each bucket of
+// the given size between since, rounded down to the start of its bucket, and
+// until.
+func bucketTodoMetrics(todos []todo.Todo, since time.Time, until time.Time, bucket string) []todoMetrics {
+	start := since.UTC().Truncate(24 * time.Hour)
+	step := func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	if bucket == metricsBucketWeek {
+		// Go back to the Monday of the week.
+		start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	}
+
+	var buckets []todoMetrics
+	for t := start; t.Before(until); t = step(t) {
+		buckets = append(buckets, todoMetrics{start: t, end: step(t)})
+	}
+
+	// Find the bucket of t, if any, with a binary search.
+	index := func(t time.Time) int {
+		lo, hi := 0, len(buckets)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			switch {
+			case t.Before(buckets[mid].start):
+				hi = mid
+			case !t.Before(buckets[mid].end):
+				lo = mid + 1
+			default:
+				return mid
+			}
+		}
+		return -1
+	}
+
+	for _, td := range todos {
+		if !td.TimeCreated.Before(until) {
+			continue
+		}
+		if i := index(td.TimeCreated); i >= 0 {
+			buckets[i].created++
+		}
+
+		if !td.Completed || !td.TimeUpdated.Before(until) {
+			continue
+		}
+		if i := index(td.TimeUpdated); i >= 0 {
+			buckets[i].completed++
+		}
+	}
+
+	return buckets
+}
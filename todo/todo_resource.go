@@ -2,12 +2,21 @@ package todo
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/sudomateo/todo/todo"
 )
@@ -18,6 +27,8 @@ var (
 	_ resource.Resource                = &todoResource{}
 	_ resource.ResourceWithConfigure   = &todoResource{}
 	_ resource.ResourceWithImportState = &todoResource{}
+	_ resource.ResourceWithIdentity    = &todoResource{}
+	_ resource.ResourceWithModifyPlan  = &todoResource{}
 )
 
 // NewTodoResource returns our implementation of this resource.
@@ -27,7 +38,7 @@ func NewTodoResource() resource.Resource {
 
 // todoResource is the concrete type that implements the Resource interface.
 type todoResource struct {
-	client *todo.Client
+	client *apiClient
 }
 
 // todoResourceModel maps resource schema data to a native Go type.
@@ -38,6 +49,35 @@ type todoResourceModel struct {
 	Completed   types.Bool   `tfsdk:"completed"`
 	TimeCreated types.String `tfsdk:"time_created"`
 	TimeUpdated types.String `tfsdk:"time_updated"`
+	Endpoint    types.String `tfsdk:"endpoint"`
+	ExtraFields types.String `tfsdk:"extra_fields"`
+	RawResponse types.String `tfsdk:"raw_response"`
+}
+
+// privateStateKeyETag is the private state key holding the ETag of the last
+// read of a todo.
+const privateStateKeyETag = "etag"
+
+// privateStateKeyTimeUpdated is the private state key holding the time_updated
+// of the todo as last seen by Terraform, used to guard updates and deletes
+// against changes made outside of Terraform.
+const privateStateKeyTimeUpdated = "time_updated"
+
+// privateStateGetter is implemented by the private state of requests.
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateStateSetter is implemented by the private state of responses.
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// todoResourceIdentityModel maps resource identity schema data to a native Go
+// type.
+type todoResourceIdentityModel struct {
+	ID       types.String `tfsdk:"id"`
+	Endpoint types.String `tfsdk:"endpoint"`
 }
 
 // Metadata returns the resource type name.
@@ -59,7 +99,9 @@ func (r *todoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Required: true,
 			},
 			"priority": schema.StringAttribute{
-				Optional: true,
+				Optional:    true,
+				Computed:    true,
+				Description: "Priority of the todo. Defaults to the default_priority of the provider.",
 			},
 			"completed": schema.BoolAttribute{
 				Computed: true,
@@ -70,10 +112,106 @@ func (r *todoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"time_updated": schema.StringAttribute{
 				Computed: true,
 			},
+			"endpoint": schema.StringAttribute{
+				Optional: true,
+				Description: "URL of the todo API managing this todo, overriding the provider host so one provider configuration can manage todos on several servers. " +
+					"Requests to it are sent without the credentials, request signature, and default headers of the provider, as they are meant for the provider host. " +
+					"Changing it creates the todo on the new server. To import a todo from another server, use an import ID of the form <endpoint>/<id>.",
+				Validators: []validator.String{
+					endpointValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"extra_fields": schema.StringAttribute{
+				Optional: true,
+				Description: "JSON-encoded object of additional fields merged into the request body when creating or updating the todo, " +
+					"for API fields the provider does not support yet. It must not set fields managed by other attributes.",
+			},
+			"raw_response": schema.StringAttribute{
+				Computed:    true,
+				Description: "JSON-encoded body of the last API response for the todo, including fields the provider does not support yet.",
+			},
+		},
+	}
+}
+
+// IdentitySchema defines the identity of the resource.
+func (r *todoResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "ID of the todo.",
+			},
+			"endpoint": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "URL of the todo API managing the todo, if not the provider host.",
+			},
 		},
 	}
 }
 
+// ModifyPlan validates the planned extra fields, and the planned priority
+// against the priority vocabulary of the provider configuration, which schema
+// validators cannot access. A priority omitted from the configuration is
+// planned as the default priority of the provider configuration.
+func (r *todoResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate when destroying.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var extraFields types.String
+	diags := req.Plan.GetAttribute(ctx, path.Root("extra_fields"), &extraFields)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !extraFields.IsUnknown() {
+		if _, err := parseExtraFields(extraFields.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("extra_fields"),
+				"Invalid extra fields",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	// Nothing more to validate before the provider is configured.
+	if r.client == nil {
+		return
+	}
+
+	var priority types.String
+	diags = req.Config.GetAttribute(ctx, path.Root("priority"), &priority)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || priority.IsUnknown() {
+		return
+	}
+	if priority.IsNull() {
+		diags = resp.Plan.SetAttribute(ctx, path.Root("priority"), types.StringValue(string(r.client.defaultPriority())))
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	// The API stores priorities exactly as sent, so a priority in a
+	// different case would come back as a perpetual diff.
+	normalized, err := r.client.allowedPriorities().parse(priority.ValueString())
+	if err == nil && string(normalized) != priority.ValueString() {
+		err = fmt.Errorf("invalid priority %q: write it as %q", priority.ValueString(), normalized)
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("priority"),
+			"Invalid priority",
+			err.Error(),
+		)
+	}
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *todoResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan.
@@ -84,10 +222,11 @@ func (r *todoResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	// Default the priority if not provided.
+	// Default the priority to the provider default if it was not planned,
+	// such as when the provider configuration was unknown during the plan.
 	priority := plan.Priority.ValueString()
-	if priority == "" {
-		priority = string(todo.PriorityLow)
+	if plan.Priority.IsUnknown() || priority == "" {
+		priority = string(r.client.defaultPriority())
 	}
 
 	// Generate an API request body from retrieved plan values.
@@ -96,8 +235,27 @@ func (r *todoResource) Create(ctx context.Context, req resource.CreateRequest, r
 		Priority: todo.Priority(priority),
 	}
 
+	extra, err := parseExtraFields(plan.ExtraFields.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("extra_fields"),
+			"Invalid extra fields",
+			err.Error(),
+		)
+		return
+	}
+
 	// Create new todo.
-	td, err := r.client.CreateTodo(params)
+	client := r.clientFor(plan.Endpoint, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	td, err := client.CreateTodoWithExtra(ctx, params, extra)
+
+	// Warn if the todo API rate limit budget is running low.
+	resp.Diagnostics.Append(client.rateLimitDiagnostics()...)
+
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating todo",
@@ -113,6 +271,7 @@ func (r *todoResource) Create(ctx context.Context, req resource.CreateRequest, r
 	plan.Completed = types.BoolValue(td.Completed)
 	plan.TimeCreated = types.StringValue(td.TimeCreated.String())
 	plan.TimeUpdated = types.StringValue(td.TimeUpdated.String())
+	plan.RawResponse = types.StringValue(string(td.Raw))
 
 	// Set the state with the values from the create operation.
 	diags = resp.State.Set(ctx, plan)
@@ -120,6 +279,20 @@ func (r *todoResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// Set the identity of the todo.
+	diags = resp.Identity.Set(ctx, todoResourceIdentityModel{ID: plan.ID, Endpoint: plan.Endpoint})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remember when the todo was last updated to guard later writes.
+	diags = setPrivateTimeUpdated(ctx, resp.Private, td.TimeUpdated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -132,8 +305,46 @@ func (r *todoResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// Get refreshed todo from the API.
-	td, err := r.client.GetTodo(state.ID.ValueString())
+	// Retrieve the ETag of the last read, if any, from private state.
+	var etag string
+	privateETag, diags := req.Private.GetKey(ctx, privateStateKeyETag)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if privateETag != nil {
+		if err := json.Unmarshal(privateETag, &etag); err != nil {
+			etag = ""
+		}
+	}
+
+	// Get refreshed todo from the API, keeping the current state if the todo
+	// has not changed since the last read.
+	client := r.clientFor(state.Endpoint, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	td, etag, err := client.GetTodoIfChanged(ctx, state.ID.ValueString(), etag)
+
+	// Warn if the todo API rate limit budget is running low.
+	resp.Diagnostics.Append(client.rateLimitDiagnostics()...)
+
+	if errors.Is(err, errNotModified) {
+		return
+	}
+
+	// Remove todos deleted outside of Terraform, such as by the
+	// todo_purge_completed action, from the state so that they are planned
+	// for creation again instead of failing every refresh.
+	if isNotFound(err) {
+		resp.Diagnostics.AddWarning(
+			"Todo deleted outside of Terraform",
+			"The todo ID "+state.ID.ValueString()+" no longer exists and was removed from the state.",
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading todo",
@@ -142,12 +353,23 @@ func (r *todoResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	// Explain any changes made outside of Terraform so plan reviewers can
+	// tell expected activity from a real problem.
+	if changes := todoDrift(state, td.Todo); len(changes) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Todo changed outside of Terraform",
+			"The todo ID "+state.ID.ValueString()+" was last updated at "+td.TimeUpdated.String()+" with the following changes:\n\n"+
+				strings.Join(changes, "\n"),
+		)
+	}
+
 	// Map response body to the schema and populate computed attributes.
 	state.Text = types.StringValue(td.Text)
 	state.Priority = types.StringValue(string(td.Priority))
 	state.Completed = types.BoolValue(td.Completed)
 	state.TimeCreated = types.StringValue(td.TimeCreated.String())
 	state.TimeUpdated = types.StringValue(td.TimeUpdated.String())
+	state.RawResponse = types.StringValue(string(td.Raw))
 
 	// Set the state with the values from the read operation.
 	diags = resp.State.Set(ctx, &state)
@@ -155,6 +377,38 @@ func (r *todoResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// Set the identity of the todo.
+	diags = resp.Identity.Set(ctx, todoResourceIdentityModel{ID: state.ID, Endpoint: state.Endpoint})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remember when the todo was last updated to guard later writes.
+	diags = setPrivateTimeUpdated(ctx, resp.Private, td.TimeUpdated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Store the ETag for the next read.
+	if etag != "" {
+		privateETag, err := json.Marshal(etag)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error storing todo ETag",
+				"Could not encode ETag for todo ID "+state.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+
+		diags = resp.Private.SetKey(ctx, privateStateKeyETag, privateETag)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -169,16 +423,52 @@ func (r *todoResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	// Generate API request body from plan.
 	text := plan.Text.ValueString()
-	priority := todo.Priority(plan.Priority.ValueString())
 	completed := plan.Completed.ValueBool()
 	params := todo.TodoUpdateParams{
 		Text:      &text,
-		Priority:  &priority,
 		Completed: &completed,
 	}
 
-	// Update existing todo.
-	td, err := r.client.UpdateTodo(plan.ID.ValueString(), params)
+	// Leave the priority unchanged if it was not planned.
+	if !plan.Priority.IsNull() && !plan.Priority.IsUnknown() {
+		priority := todo.Priority(plan.Priority.ValueString())
+		params.Priority = &priority
+	}
+
+	extra, err := parseExtraFields(plan.ExtraFields.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("extra_fields"),
+			"Invalid extra fields",
+			err.Error(),
+		)
+		return
+	}
+
+	// Update existing todo, unless it changed since Terraform last read it.
+	since, diags := getPrivateTimeUpdated(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.clientFor(plan.Endpoint, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	td, err := client.UpdateTodoIfUnmodified(ctx, plan.ID.ValueString(), params, extra, since)
+
+	// Warn if the todo API rate limit budget is running low.
+	resp.Diagnostics.Append(client.rateLimitDiagnostics()...)
+
+	if errors.Is(err, errConflict) {
+		resp.Diagnostics.AddError(
+			"Conflict updating todo",
+			conflictDetail(plan.ID.ValueString()),
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating todo",
@@ -193,6 +483,7 @@ func (r *todoResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	plan.Completed = types.BoolValue(td.Completed)
 	plan.TimeCreated = types.StringValue(td.TimeCreated.String())
 	plan.TimeUpdated = types.StringValue(td.TimeUpdated.String())
+	plan.RawResponse = types.StringValue(string(td.Raw))
 
 	// Set the state with the values from the update operation.
 	diags = resp.State.Set(ctx, plan)
@@ -200,6 +491,20 @@ func (r *todoResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// Set the identity of the todo.
+	diags = resp.Identity.Set(ctx, todoResourceIdentityModel{ID: plan.ID, Endpoint: plan.Endpoint})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remember when the todo was last updated to guard later writes.
+	diags = setPrivateTimeUpdated(ctx, resp.Private, td.TimeUpdated)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -212,8 +517,30 @@ func (r *todoResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	// Delete existing todo.
-	err := r.client.DeleteTodo(state.ID.ValueString())
+	// Delete existing todo, unless it changed since Terraform last read it.
+	since, diags := getPrivateTimeUpdated(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.clientFor(state.Endpoint, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := client.DeleteTodoIfUnmodified(ctx, state.ID.ValueString(), since)
+
+	// Warn if the todo API rate limit budget is running low.
+	resp.Diagnostics.Append(client.rateLimitDiagnostics()...)
+
+	if errors.Is(err, errConflict) {
+		resp.Diagnostics.AddError(
+			"Conflict deleting todo",
+			conflictDetail(state.ID.ValueString()),
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting todo",
@@ -223,17 +550,146 @@ func (r *todoResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 }
 
+// todoDrift describes the attributes of state that differ from td, one line
+// per attribute. Attributes without a prior value, such as after an import,
+// are not reported.
+func todoDrift(state todoResourceModel, td todo.Todo) []string {
+	var changes []string
+
+	if !state.Text.IsNull() && state.Text.ValueString() != td.Text {
+		changes = append(changes, fmt.Sprintf("  - text: %q -> %q", state.Text.ValueString(), td.Text))
+	}
+
+	if !state.Priority.IsNull() && state.Priority.ValueString() != string(td.Priority) {
+		changes = append(changes, fmt.Sprintf("  - priority: %q -> %q", state.Priority.ValueString(), td.Priority))
+	}
+
+	if !state.Completed.IsNull() && state.Completed.ValueBool() != td.Completed {
+		changes = append(changes, fmt.Sprintf("  - completed: %t -> %t", state.Completed.ValueBool(), td.Completed))
+	}
+
+	return changes
+}
+
+// conflictDetail describes a conditional write of the todo id that was
+// rejected because the todo changed outside of Terraform.
+func conflictDetail(id string) string {
+	return "The todo ID " + id + " was modified outside of Terraform since it was last read, so the change was not applied to avoid overwriting it. " +
+		"Refresh the state, for example with terraform apply -refresh-only, review the changes, and then apply again."
+}
+
+// getPrivateTimeUpdated returns the time_updated stored in private state, or
+// the zero time if there is none.
+func getPrivateTimeUpdated(ctx context.Context, private privateStateGetter) (time.Time, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, privateStateKeyTimeUpdated)
+	if diags.HasError() || value == nil {
+		return time.Time{}, diags
+	}
+
+	var t time.Time
+	if err := json.Unmarshal(value, &t); err != nil {
+		return time.Time{}, diags
+	}
+
+	return t, diags
+}
+
+// setPrivateTimeUpdated stores t as the time_updated in private state.
+func setPrivateTimeUpdated(ctx context.Context, private privateStateSetter, t time.Time) diag.Diagnostics {
+	value, err := json.Marshal(t)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Error storing todo update time",
+			"Could not encode update time: "+err.Error(),
+		)
+		return diags
+	}
+
+	return private.SetKey(ctx, privateStateKeyTimeUpdated, value)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *todoResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	r.client = req.ProviderData.(*todo.Client)
+	r.client = req.ProviderData.(*apiClient)
+}
+
+// clientFor returns the client for the todo API at endpoint, or the provider
+// configured client if endpoint is null, adding an error to diags if the
+// endpoint is invalid.
+func (r *todoResource) clientFor(endpoint types.String, diags *diag.Diagnostics) *apiClient {
+	if endpoint.IsNull() || endpoint.IsUnknown() {
+		return r.client
+	}
+
+	client, err := r.client.forEndpoint(endpoint.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("endpoint"),
+			"Invalid todo API endpoint",
+			"The provider cannot create a todo API client for the endpoint: "+err.Error(),
+		)
+		return nil
+	}
+
+	return client
 }
 
 // ImportState uses a resources Read method to implement import.
 func (r *todoResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute.
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	endpoint := types.StringNull()
+
+	if req.ID != "" {
+		// Import IDs of the form <endpoint>/<id> import a todo from another
+		// todo API than the provider host.
+		importEndpoint, id := splitImportID(req.ID)
+		if _, err := uuid.Parse(id); err != nil {
+			resp.Diagnostics.AddError(
+				"Error importing todo",
+				"Import ID "+req.ID+" must be a todo ID or of the form <endpoint>/<id>: "+err.Error(),
+			)
+			return
+		}
+
+		if importEndpoint != "" {
+			endpoint = types.StringValue(importEndpoint)
+		}
+		req.ID = id
+	} else {
+		var identity todoResourceIdentityModel
+		diags := req.Identity.Get(ctx, &identity)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		endpoint = identity.Endpoint
+	}
+
+	if !endpoint.IsNull() {
+		diags := resp.State.SetAttribute(ctx, path.Root("endpoint"), endpoint)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Retrieve import ID or identity and save to id attribute.
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
+
+// splitImportID splits an import ID of the form <endpoint>/<id> into its
+// endpoint and todo ID. Import IDs without an endpoint URL are returned as the
+// todo ID with an empty endpoint.
+func splitImportID(importID string) (endpoint string, id string) {
+	if !strings.Contains(importID, "://") {
+		return "", importID
+	}
+
+	i := strings.LastIndex(importID, "/")
+	return importID[:i], importID[i+1:]
 }
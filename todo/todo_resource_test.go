@@ -0,0 +1,37 @@
+package todo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sudomateo/todo/todo"
+)
+
+func TestTodoResourceReadRemovesDeletedTodo(t *testing.T) {
+	r := &todoResource{client: newMockTestClient(t, newMockName("resource-read"), apiClientOptions{})}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	state := newTestState(t, tfsdk.State{Schema: schemaResp.Schema}, &todoResourceModel{
+		ID:       types.StringValue(uuid.NewString()),
+		Text:     types.StringValue("Buy milk"),
+		Priority: types.StringValue(string(todo.PriorityLow)),
+	})
+
+	resp := resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read() diagnostics = %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("Read() warnings = %v, want a warning about the deleted todo", resp.Diagnostics.Warnings())
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("deleted todo was not removed from the state")
+	}
+}
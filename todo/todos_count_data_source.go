@@ -0,0 +1,138 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sudomateo/todo/todo"
+)
+
+// Compile-time assertions that our concrete todosCountDataSource implements
+// the necessary interfaces for a data source.
+var (
+	_ datasource.DataSource              = &todosCountDataSource{}
+	_ datasource.DataSourceWithConfigure = &todosCountDataSource{}
+)
+
+// NewTodosCountDataSource returns our implementation of this data source.
+func NewTodosCountDataSource() datasource.DataSource {
+	return &todosCountDataSource{}
+}
+
+// todosCountDataSource is the concrete type that implements the DataSource
+// interface.
+type todosCountDataSource struct {
+	client *apiClient
+}
+
+// todosCountDataSourceModel maps data source schema data to a native Go type.
+type todosCountDataSourceModel struct {
+	ID        types.String          `tfsdk:"id"`
+	Priority  types.String          `tfsdk:"priority"`
+	Completed types.Bool            `tfsdk:"completed"`
+	Count     types.Int64           `tfsdk:"count"`
+	Retry     *dataSourceRetryModel `tfsdk:"retry"`
+	Timeout   types.String          `tfsdk:"timeout"`
+}
+
+// Metadata returns the data source type name.
+func (d *todosCountDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_todos_count"
+}
+
+// Schema defines the configuration for the data source block.
+func (d *todosCountDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"priority": schema.StringAttribute{
+				Optional: true,
+			},
+			"completed": schema.BoolAttribute{
+				Optional: true,
+			},
+			"count": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+	}
+
+	for name, attribute := range dataSourceRetrySchema() {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todosCountDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state todosCountDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate the priority filter against the configured vocabulary.
+	priority := parsePriorityFilter(state.Priority, path.Root("priority"), d.client.allowedPriorities(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The todo API has no count endpoint, so count the matching todos from
+	// the list endpoint without storing them in state.
+	var todos []todo.Todo
+	err := newReadRetryPolicy(state.Retry, state.Timeout).do(ctx, func(ctx context.Context) error {
+		var err error
+		todos, err = d.client.ListTodos(ctx)
+		return err
+	})
+
+	// Warn if the todo API rate limit budget is running low.
+	resp.Diagnostics.Append(d.client.rateLimitDiagnostics()...)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read todos",
+			err.Error(),
+		)
+		return
+	}
+
+	var count int64
+	for _, todo := range todos {
+		if priority != "" && todo.Priority != priority {
+			continue
+		}
+
+		if !state.Completed.IsNull() && todo.Completed != state.Completed.ValueBool() {
+			continue
+		}
+
+		count++
+	}
+
+	state.Count = types.Int64Value(count)
+
+	// Set the data source ID to a placeholder value for testing.
+	state.ID = types.StringValue("todos_count_id_placeholder")
+
+	// Set the state with the values from the read operation.
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todosCountDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*apiClient)
+}
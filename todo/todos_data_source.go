@@ -2,9 +2,13 @@ package todo
 
 import (
 	"context"
+	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/sudomateo/todo/todo"
 )
@@ -24,13 +28,22 @@ func NewTodosDataSource() datasource.DataSource {
 // todosDataSource is the concrete type that implements the DataSource
 // interface.
 type todosDataSource struct {
-	client *todo.Client
+	client *apiClient
 }
 
 // todosDataSourceModel maps data source schema data to a native Go type.
 type todosDataSourceModel struct {
-	ID    types.String `tfsdk:"id"`
-	Todos []todosModel `tfsdk:"todos"`
+	ID            types.String              `tfsdk:"id"`
+	GroupBy       types.String              `tfsdk:"group_by"`
+	Limit         types.Int64               `tfsdk:"limit"`
+	Todos         []todosModel              `tfsdk:"todos"`
+	Groups        map[string][]types.String `tfsdk:"groups"`
+	GroupCounts   map[string]types.Int64    `tfsdk:"group_counts"`
+	TotalCount    types.Int64               `tfsdk:"total_count"`
+	ReturnedCount types.Int64               `tfsdk:"returned_count"`
+	Truncated     types.Bool                `tfsdk:"truncated"`
+	Retry         *dataSourceRetryModel     `tfsdk:"retry"`
+	Timeout       types.String              `tfsdk:"timeout"`
 }
 
 // todosModel maps data source schema data to a native Go type.
@@ -51,10 +64,48 @@ func (d *todosDataSource) Metadata(_ context.Context, req datasource.MetadataReq
 // Schema defines the configuration for the data source block.
 func (d *todosDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Description: "Lists the todos matching the given filters, optionally limited to the first limit todos and grouped by group_by. " +
+			"total_count and group_counts count every matching todo, while todos and groups only hold the returned ones.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"group_by": schema.StringAttribute{
+				Optional:    true,
+				Description: "Attribute to group the todos by in groups and group_counts: priority or completed.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("priority", "completed"),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of todos to return in todos. Todos beyond the limit are still counted in total_count and group_counts.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"total_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of todos, including those left out by limit.",
+			},
+			"returned_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of todos returned in todos.",
+			},
+			"truncated": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether limit left out any todos.",
+			},
+			"groups": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.ListType{ElemType: types.StringType},
+				Description: "IDs of the returned todos, keyed by the value of the group_by attribute. Like todos, it leaves out the todos beyond limit. Null unless group_by is set.",
+			},
+			"group_counts": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.Int64Type,
+				Description: "Number of todos, keyed by the value of the group_by attribute. Like total_count, it includes the todos beyond limit. Null unless group_by is set.",
+			},
 			"todos": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -82,13 +133,83 @@ func (d *todosDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 			},
 		},
 	}
+
+	for name, attribute := range dataSourceRetrySchema() {
+		resp.Schema.Attributes[name] = attribute
+	}
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (d *todosDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state todosDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Stream the todos from the API, building the Terraform values as each
+	// todo is decoded so that the full list is never held in memory twice.
+	// The accumulated values are reset on every attempt.
+	err := newReadRetryPolicy(state.Retry, state.Timeout).do(ctx, func(ctx context.Context) error {
+		var total int64
+		state.Todos = nil
+		state.Groups = nil
+		state.GroupCounts = nil
+		if !state.GroupBy.IsNull() {
+			state.Groups = make(map[string][]types.String)
+			state.GroupCounts = make(map[string]types.Int64)
+		}
+
+		err := d.client.EachTodo(ctx, func(todo todo.Todo) error {
+			total++
+
+			// Determine the group of the todo by the requested attribute, if
+			// any, and count it before clipping so that group counts cover
+			// every todo, like the total count.
+			var key string
+			if !state.GroupBy.IsNull() {
+				switch state.GroupBy.ValueString() {
+				case "priority":
+					key = string(todo.Priority)
+				case "completed":
+					key = strconv.FormatBool(todo.Completed)
+				}
+
+				state.GroupCounts[key] = types.Int64Value(state.GroupCounts[key].ValueInt64() + 1)
+			}
+
+			// Clip the result set to the requested limit while still counting
+			// the todos that were left out.
+			if !state.Limit.IsNull() && int64(len(state.Todos)) >= state.Limit.ValueInt64() {
+				return nil
+			}
+
+			// Map response body to the schema and populate computed attributes.
+			state.Todos = append(state.Todos, todosModel{
+				ID:          types.StringValue(todo.ID.String()),
+				Text:        types.StringValue(todo.Text),
+				Priority:    types.StringValue(string(todo.Priority)),
+				Completed:   types.BoolValue(todo.Completed),
+				TimeCreated: types.StringValue(todo.TimeCreated.String()),
+				TimeUpdated: types.StringValue(todo.TimeUpdated.String()),
+			})
+
+			// Group the IDs of the returned todos.
+			if !state.GroupBy.IsNull() {
+				state.Groups[key] = append(state.Groups[key], types.StringValue(todo.ID.String()))
+			}
+
+			return nil
+		})
+
+		state.TotalCount = types.Int64Value(total)
+		return err
+	})
+
+	// Warn if the todo API rate limit budget is running low.
+	resp.Diagnostics.Append(d.client.rateLimitDiagnostics()...)
 
-	todos, err := d.client.ListTodos()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read todos",
@@ -97,25 +218,16 @@ func (d *todosDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	// Map response body to the schema and populate computed attributes.
-	for _, todo := range todos {
-		todostate := todosModel{
-			ID:          types.StringValue(todo.ID.String()),
-			Text:        types.StringValue(todo.Text),
-			Priority:    types.StringValue(string(todo.Priority)),
-			Completed:   types.BoolValue(todo.Completed),
-			TimeCreated: types.StringValue(todo.TimeCreated.String()),
-			TimeUpdated: types.StringValue(todo.TimeUpdated.String()),
-		}
-
-		state.Todos = append(state.Todos, todostate)
-	}
+	// Record whether anything was left out so consumers don't silently
+	// operate on partial data.
+	state.ReturnedCount = types.Int64Value(int64(len(state.Todos)))
+	state.Truncated = types.BoolValue(state.ReturnedCount.ValueInt64() < state.TotalCount.ValueInt64())
 
 	// Set the data source ID to a placeholder value for testing.
 	state.ID = types.StringValue("todos_id_placeholder")
 
 	// Set the state with the values from the read operation.
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -128,5 +240,5 @@ func (d *todosDataSource) Configure(_ context.Context, req datasource.ConfigureR
 		return
 	}
 
-	d.client = req.ProviderData.(*todo.Client)
+	d.client = req.ProviderData.(*apiClient)
 }
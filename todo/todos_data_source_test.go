@@ -0,0 +1,76 @@
+package todo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sudomateo/todo/todo"
+)
+
+func TestTodosDataSourceReadGroups(t *testing.T) {
+	name := newMockName("todos-groups")
+
+	now := time.Now()
+	seedMockTodos(t, name,
+		todo.Todo{Text: "Rotate certs", Priority: todo.PriorityHigh, TimeCreated: now, TimeUpdated: now},
+		todo.Todo{Text: "Patch servers", Priority: todo.PriorityHigh, TimeCreated: now, TimeUpdated: now},
+		todo.Todo{Text: "Water plants", Priority: todo.PriorityLow, TimeCreated: now, TimeUpdated: now},
+	)
+
+	tests := []struct {
+		name         string
+		limit        types.Int64
+		wantReturned int64
+		wantGroups   int
+	}{
+		{name: "no limit", limit: types.Int64Null(), wantReturned: 3, wantGroups: 3},
+		{name: "limit", limit: types.Int64Value(1), wantReturned: 1, wantGroups: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &todosDataSource{client: newMockTestClient(t, name, apiClientOptions{})}
+
+			var schemaResp datasource.SchemaResponse
+			d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+			config := newTestState(t, tfsdk.State{Schema: schemaResp.Schema}, &todosDataSourceModel{
+				GroupBy: types.StringValue("priority"),
+				Limit:   tt.limit,
+			})
+
+			resp := datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+			d.Read(context.Background(), datasource.ReadRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Read() diagnostics = %v", resp.Diagnostics)
+			}
+
+			var state todosDataSourceModel
+			if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			if state.TotalCount.ValueInt64() != 3 || state.ReturnedCount.ValueInt64() != tt.wantReturned {
+				t.Errorf("total_count = %v, returned_count = %v, want 3 and %d", state.TotalCount, state.ReturnedCount, tt.wantReturned)
+			}
+
+			// Group counts cover every todo, while groups only hold the
+			// returned ones.
+			if high, low := state.GroupCounts["high"].ValueInt64(), state.GroupCounts["low"].ValueInt64(); high != 2 || low != 1 {
+				t.Errorf("group_counts = %v, want 2 high and 1 low", state.GroupCounts)
+			}
+
+			var grouped int
+			for _, ids := range state.Groups {
+				grouped += len(ids)
+			}
+			if grouped != tt.wantGroups {
+				t.Errorf("groups hold %d IDs, want %d", grouped, tt.wantGroups)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Compile-time assertions that our concrete todosListResource implements the
+// necessary interfaces for a list resource.
+var (
+	_ list.ListResource              = &todosListResource{}
+	_ list.ListResourceWithConfigure = &todosListResource{}
+)
+
+// NewTodosListResource returns our implementation of this list resource.
+func NewTodosListResource() list.ListResource {
+	return &todosListResource{}
+}
+
+// todosListResource is the concrete type that implements the ListResource
+// interface for the todo resource.
+type todosListResource struct {
+	client *apiClient
+}
+
+// todosListResourceModel maps list resource schema data to a native Go type.
+type todosListResourceModel struct {
+	Priority  types.String `tfsdk:"priority"`
+	Completed types.Bool   `tfsdk:"completed"`
+}
+
+// Metadata returns the resource type name being listed.
+func (r *todosListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_todo"
+}
+
+// ListResourceConfigSchema defines the configuration for the list block.
+func (r *todosListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"priority": schema.StringAttribute{
+				Optional: true,
+			},
+			"completed": schema.BoolAttribute{
+				Optional: true,
+			},
+		},
+	}
+}
+
+// List streams the todos matching the list block configuration.
+func (r *todosListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config todosListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	// Validate the priority filter against the configured vocabulary.
+	priority := parsePriorityFilter(config.Priority, path.Root("priority"), r.client.allowedPriorities(), &diags)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	todos, err := r.client.ListTodos(ctx)
+	if err != nil {
+		diags.AddError(
+			"Unable to list todos",
+			err.Error(),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, td := range todos {
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+
+			if priority != "" && td.Priority != priority {
+				continue
+			}
+
+			if !config.Completed.IsNull() && td.Completed != config.Completed.ValueBool() {
+				continue
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = td.Text
+
+			// Set the identity of the todo so Terraform can import it.
+			result.Diagnostics.Append(result.Identity.Set(ctx, todoResourceIdentityModel{
+				ID: types.StringValue(td.ID.String()),
+			})...)
+
+			// Map response body to the resource schema when requested.
+			if req.IncludeResource {
+				result.Diagnostics.Append(result.Resource.Set(ctx, todoResourceModel{
+					ID:          types.StringValue(td.ID.String()),
+					Text:        types.StringValue(td.Text),
+					Priority:    types.StringValue(string(td.Priority)),
+					Completed:   types.BoolValue(td.Completed),
+					TimeCreated: types.StringValue(td.TimeCreated.String()),
+					TimeUpdated: types.StringValue(td.TimeUpdated.String()),
+				})...)
+			}
+
+			count++
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// Configure adds the provider configured client to the list resource.
+func (r *todosListResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*apiClient)
+}
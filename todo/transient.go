@@ -0,0 +1,132 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	// defaultMaxRetries is the number of times an idempotent request is
+	// retried before a transient failure is returned to the caller, unless
+	// configured otherwise.
+	defaultMaxRetries = 2
+
+	// transientRetryBaseWait and defaultRetryMaxWait bound the jittered
+	// exponential backoff between transient retries. The maximum wait can
+	// be configured.
+	transientRetryBaseWait = 250 * time.Millisecond
+	defaultRetryMaxWait    = 2 * time.Second
+)
+
+// isIdempotent reports whether req can safely be sent more than once. Creates
+// are only idempotent when they carry an idempotency key, which is only sent
+// when the server is known to honor it.
+//
+// Conditional writes are never idempotent: if an attempt was applied but its
+// response was lost, a retry fails its precondition against the change the
+// attempt itself made, reporting a conflict that did not happen. So updates
+// are never retried, guarded or not, and deletes only when unguarded.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodDelete:
+		return req.Header.Get("If-Match") == "" && req.Header.Get("If-Unmodified-Since") == ""
+	case http.MethodPost:
+		return req.Header.Get(idempotencyKeyHeader) != ""
+	}
+
+	return false
+}
+
+// isTransient reports whether a response or transport error is likely to
+// succeed when retried.
+func isTransient(resp *http.Response, err error) bool {
+	if err != nil {
+		return errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, syscall.ECONNREFUSED) ||
+			errors.Is(err, io.ErrUnexpectedEOF) ||
+			errors.Is(err, io.EOF)
+	}
+
+	return isTransientStatus(resp.StatusCode)
+}
+
+// isTransientStatus reports whether a response with the given status code is
+// likely to succeed when retried: rate limited requests and server errors,
+// except for features the server does not implement.
+func isTransientStatus(code int) bool {
+	return code == http.StatusTooManyRequests ||
+		(code >= 500 && code != http.StatusNotImplemented)
+}
+
+// isTransientError reports whether an error returned by an apiClient method
+// is likely to go away when the call is retried.
+func isTransientError(err error) bool {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		return isTransientStatus(apiErr.StatusCode)
+	}
+
+	return isTransient(nil, err)
+}
+
+// transientRetriesDisabledKey is the context key marking calls whose
+// requests must not be retried by the apiClient.
+type transientRetriesDisabledKey struct{}
+
+// withoutTransientRetries returns a copy of ctx in which the apiClient does
+// not retry requests after transient failures, for callers that retry the
+// whole call themselves.
+func withoutTransientRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, transientRetriesDisabledKey{}, true)
+}
+
+// transientRetriesDisabled reports whether ctx was returned by
+// withoutTransientRetries.
+func transientRetriesDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(transientRetriesDisabledKey{}).(bool)
+	return disabled
+}
+
+// transientRetryWait returns a jittered wait of at most maxWait before the
+// given retry attempt. A Retry-After header of resp, if any, is honored up to
+// maxWait.
+func transientRetryWait(attempt int, maxWait time.Duration, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, maxWait)
+		}
+	}
+
+	wait := transientRetryBaseWait << (attempt - 1)
+	if wait > maxWait || wait <= 0 {
+		wait = maxWait
+	}
+	if wait <= 0 {
+		return 0
+	}
+
+	// Use full jitter so that many resources failing at once don't retry in
+	// lockstep.
+	return rand.N(wait) + 1
+}
+
+// sleepWithContext waits for d or until ctx is done, whichever comes first.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,124 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sudomateo/terraform-provider-todo/internal/mockapi"
+	"github.com/sudomateo/todo/todo"
+)
+
+// lostResponseTransport serves requests from the mock todo API at name,
+// enforcing If-Unmodified-Since like a server supporting conditional writes.
+// The response to the first request is replaced with a 503, as if it was lost
+// after the server applied the request.
+type lostResponseTransport struct {
+	name     string
+	requests atomic.Int32
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *lostResponseTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	n := t.requests.Add(1)
+
+	if since, err := http.ParseTime(req.Header.Get("If-Unmodified-Since")); err == nil {
+		id, _ := uuid.Parse(path.Base(req.URL.Path))
+		td, err := mockapi.Store(t.name).QueryByID(req.Context(), id)
+		if err == nil && td.TimeUpdated.Truncate(time.Second).After(since) {
+			return &http.Response{StatusCode: http.StatusPreconditionFailed, Header: make(http.Header), Body: http.NoBody, Request: req}, nil
+		}
+	}
+
+	resp, err := mockTransport{handler: mockapi.NewHandler(mockapi.Store(t.name))}.RoundTrip(req)
+	if err != nil || n > 1 {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: make(http.Header), Body: http.NoBody, Request: req}, nil
+}
+
+func TestAPIClientRetriesAfterLostResponse(t *testing.T) {
+	text := "Buy eggs"
+
+	tests := []struct {
+		name         string
+		call         func(ctx context.Context, c *apiClient, td todo.Todo) error
+		wantRequests int32
+		wantErr      bool
+	}{
+		{
+			name: "get",
+			call: func(ctx context.Context, c *apiClient, td todo.Todo) error {
+				_, err := c.GetTodo(ctx, td.ID.String())
+				return err
+			},
+			wantRequests: 2,
+		},
+		{
+			name: "delete",
+			call: func(ctx context.Context, c *apiClient, td todo.Todo) error {
+				return c.DeleteTodo(ctx, td.ID.String())
+			},
+			wantRequests: 2,
+		},
+		{
+			name: "update",
+			call: func(ctx context.Context, c *apiClient, td todo.Todo) error {
+				_, err := c.UpdateTodo(ctx, td.ID.String(), todo.TodoUpdateParams{Text: &text})
+				return err
+			},
+			wantRequests: 1,
+			wantErr:      true,
+		},
+		{
+			name: "guarded update",
+			call: func(ctx context.Context, c *apiClient, td todo.Todo) error {
+				_, err := c.UpdateTodoIfUnmodified(ctx, td.ID.String(), todo.TodoUpdateParams{Text: &text}, nil, td.TimeUpdated)
+				return err
+			},
+			wantRequests: 1,
+			wantErr:      true,
+		},
+		{
+			name: "guarded delete",
+			call: func(ctx context.Context, c *apiClient, td todo.Todo) error {
+				return c.DeleteTodoIfUnmodified(ctx, td.ID.String(), td.TimeUpdated)
+			},
+			wantRequests: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := newMockName("lost-response")
+
+			updated := time.Now().Add(-time.Hour)
+			td := seedMockTodos(t, name, todo.Todo{Text: "Buy milk", Priority: todo.PriorityLow, TimeCreated: updated, TimeUpdated: updated})[0]
+
+			transport := &lostResponseTransport{name: name}
+			c, err := newAPIClient(mockScheme+"://"+name, &http.Client{Transport: transport}, apiClientOptions{maxRetries: 2})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = tt.call(context.Background(), c, td)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, errConflict) {
+				t.Errorf("error = %v, want the lost response instead of a conflict", err)
+			}
+			if got := transport.requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Compile-time assertions that our concrete validatePriorityFunction
+// implements the Function interface.
+var (
+	_ function.Function = &validatePriorityFunction{}
+)
+
+// NewValidatePriorityFunction returns our implementation of this function.
+func NewValidatePriorityFunction() function.Function {
+	return &validatePriorityFunction{}
+}
+
+// validatePriorityFunction is the concrete type that implements the Function
+// interface.
+type validatePriorityFunction struct{}
+
+// Metadata returns the function name.
+func (f *validatePriorityFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_priority"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *validatePriorityFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Validate and normalize a todo priority",
+		Description: "Returns the priority trimmed and lowercased, or an error if it is not a valid todo priority.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "priority",
+				Description: "The todo priority to validate.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run validates the priority argument and returns its normalized form.
+func (f *validatePriorityFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var priority string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &priority))
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := parsePriority(priority)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, string(normalized)))
+}
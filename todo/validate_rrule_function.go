@@ -0,0 +1,58 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Compile-time assertions that our concrete validateRRuleFunction implements
+// the Function interface.
+var (
+	_ function.Function = &validateRRuleFunction{}
+)
+
+// NewValidateRRuleFunction returns our implementation of this function.
+func NewValidateRRuleFunction() function.Function {
+	return &validateRRuleFunction{}
+}
+
+// validateRRuleFunction is the concrete type that implements the Function
+// interface.
+type validateRRuleFunction struct{}
+
+// Metadata returns the function name.
+func (f *validateRRuleFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_rrule"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *validateRRuleFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Validate a recurrence rule",
+		Description: "Returns the RFC 5545 recurrence rule unchanged, or an error describing why it cannot be parsed.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "rule",
+				Description: "The recurrence rule to validate, such as \"FREQ=WEEKLY;BYDAY=MO\".",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run validates the rule argument.
+func (f *validateRRuleFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rule string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &rule))
+	if resp.Error != nil {
+		return
+	}
+
+	if err := validateRRule(rule); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "invalid rule: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, rule))
+}
@@ -0,0 +1,84 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Compile-time assertions that our concrete validators implement the
+// necessary validator interfaces.
+var (
+	_ validator.String = durationValidator{}
+	_ validator.String = endpointValidator{}
+)
+
+// durationValidator validates that a string attribute is a valid Go duration
+// such as "30s" or "5m".
+type durationValidator struct{}
+
+// Description describes the validation in plain text formatting.
+func (v durationValidator) Description(_ context.Context) string {
+	return `value must be a duration string such as "30s" or "5m"`
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid duration",
+			"The value "+req.ConfigValue.String()+" is not a valid duration: "+err.Error(),
+		)
+		return
+	}
+
+	if d < 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid duration",
+			"The value "+req.ConfigValue.String()+" must not be negative.",
+		)
+	}
+}
+
+// endpointValidator validates that a string attribute is a todo API URL such
+// as "https://todo.example.com", or a host name to use with the default
+// scheme.
+type endpointValidator struct{}
+
+// Description describes the validation in plain text formatting.
+func (v endpointValidator) Description(_ context.Context) string {
+	return `value must be a URL such as "https://todo.example.com"`
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v endpointValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v endpointValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := normalizeEndpoint(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid todo API endpoint",
+			"The value "+req.ConfigValue.String()+" is not a valid todo API endpoint: "+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,364 @@
+package todo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sync"
+)
+
+const (
+	// vcrModeRecord records every interaction with the todo API to the
+	// cassette.
+	vcrModeRecord = "record"
+
+	// vcrModeReplay serves requests from the cassette without contacting the
+	// todo API.
+	vcrModeReplay = "replay"
+)
+
+// vcrRedacted replaces the values of credential headers and body fields in
+// cassettes.
+const vcrRedacted = "REDACTED"
+
+// vcrScrubbedHeaders lists the headers whose values never end up in a
+// cassette.
+var vcrScrubbedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+	"X-Api-Key",
+}
+
+// vcrScrubbedFields lists the JSON and form body fields whose values never
+// end up in a cassette, such as the tokens of OAuth token responses.
+var vcrScrubbedFields = []string{
+	"access_token",
+	"client_secret",
+	"id_token",
+	"password",
+	"refresh_token",
+	"token",
+}
+
+// vcrCassette is the on-disk format of recorded interactions.
+type vcrCassette struct {
+	Interactions []vcrInteraction `json:"interactions"`
+}
+
+// vcrInteraction is a single recorded request and its response.
+type vcrInteraction struct {
+	Request  vcrRequest  `json:"request"`
+	Response vcrResponse `json:"response"`
+}
+
+// vcrRequest is a recorded request.
+type vcrRequest struct {
+	Method string      `json:"method"`
+	URI    string      `json:"uri"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// vcrResponse is a recorded response.
+type vcrResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// vcrTransport is an http.RoundTripper that records interactions with the
+// todo API to a cassette file or replays them from it, so tests can be
+// recorded once against a real server and replayed offline.
+//
+// Requests are matched on method, request URI and body, ignoring the host, in
+// the order they were recorded. All transports of a process share the
+// interactions of a cassette, so recording appends to the cassette and
+// replaying continues where the previous provider configuration stopped. To
+// record a cassette again, delete it first.
+type vcrTransport struct {
+	mode     string
+	base     http.RoundTripper
+	cassette *vcrCassetteFile
+}
+
+// vcrCassetteFile holds the interactions of a cassette file shared by the
+// vcrTransports using it.
+type vcrCassetteFile struct {
+	path string
+
+	mu       sync.Mutex
+	cassette vcrCassette
+	used     []bool
+}
+
+// vcrCassetteKey identifies a shared cassette.
+type vcrCassetteKey struct {
+	mode string
+	path string
+}
+
+var (
+	vcrCassettesMu sync.Mutex
+	vcrCassettes   = make(map[vcrCassetteKey]*vcrCassetteFile)
+)
+
+// newVCRTransport returns a vcrTransport in the given mode for the cassette
+// at path. In record mode requests are sent using base.
+func newVCRTransport(mode string, path string, base http.RoundTripper) (*vcrTransport, error) {
+	if path == "" {
+		return nil, fmt.Errorf("a cassette path is required in %s mode", mode)
+	}
+
+	if mode != vcrModeRecord && mode != vcrModeReplay {
+		return nil, fmt.Errorf("invalid mode %q, must be %q or %q", mode, vcrModeRecord, vcrModeReplay)
+	}
+
+	cassette, err := openVCRCassette(mode, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vcrTransport{
+		mode:     mode,
+		base:     base,
+		cassette: cassette,
+	}, nil
+}
+
+// openVCRCassette returns the shared cassette at path, loading it on first
+// use. A missing cassette is only allowed in record mode.
+func openVCRCassette(mode string, path string) (*vcrCassetteFile, error) {
+	vcrCassettesMu.Lock()
+	defer vcrCassettesMu.Unlock()
+
+	key := vcrCassetteKey{mode: mode, path: path}
+	if c, ok := vcrCassettes[key]; ok {
+		return c, nil
+	}
+
+	c := vcrCassetteFile{path: path}
+
+	buf, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist) && mode == vcrModeRecord:
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(buf, &c.cassette); err != nil {
+			return nil, fmt.Errorf("decoding cassette %s: %w", path, err)
+		}
+	}
+	c.used = make([]bool, len(c.cassette.Interactions))
+
+	vcrCassettes[key] = &c
+
+	return &c, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *vcrTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.mode == vcrModeReplay {
+		return t.replay(req)
+	}
+
+	return t.record(req)
+}
+
+// replay returns the first unused recorded response matching req.
+func (t *vcrTransport) replay(req *http.Request) (*http.Response, error) {
+	reqBody, err := vcrReadBody(req)
+	if err != nil {
+		return nil, err
+	}
+	body := vcrScrubBody(req.Header, reqBody)
+
+	c := t.cassette
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, interaction := range c.cassette.Interactions {
+		if c.used[i] || interaction.Request.Method != req.Method || interaction.Request.URI != req.URL.RequestURI() || interaction.Request.Body != body {
+			continue
+		}
+		c.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewBufferString(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction in cassette %s matches %s %s", c.path, req.Method, req.URL.RequestURI())
+}
+
+// record sends req and saves the interaction to the cassette.
+func (t *vcrTransport) record(req *http.Request) (*http.Response, error) {
+	reqBody, err := vcrReadBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	c := t.cassette
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cassette.Interactions = append(c.cassette.Interactions, vcrInteraction{
+		Request: vcrRequest{
+			Method: req.Method,
+			URI:    req.URL.RequestURI(),
+			Header: vcrScrub(req.Header),
+			Body:   vcrScrubBody(req.Header, reqBody),
+		},
+		Response: vcrResponse{
+			StatusCode: resp.StatusCode,
+			Header:     vcrScrub(resp.Header),
+			Body:       vcrScrubBody(resp.Header, respBody),
+		},
+	})
+	c.used = append(c.used, true)
+
+	// Save after every interaction so that a cassette is written even if the
+	// provider is stopped abruptly. Cassettes may hold personal data, so they
+	// are only readable by their owner.
+	buf, err := json.MarshalIndent(c.cassette, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(c.path, buf, 0o600); err != nil {
+		return nil, fmt.Errorf("writing cassette %s: %w", c.path, err)
+	}
+
+	return resp, nil
+}
+
+// vcrReadBody reads the body of req, leaving it readable again.
+func vcrReadBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// vcrScrub returns a copy of header with credentials redacted.
+func vcrScrub(header http.Header) http.Header {
+	scrubbed := header.Clone()
+	for _, key := range vcrScrubbedHeaders {
+		if scrubbed.Get(key) != "" {
+			scrubbed.Set(key, vcrRedacted)
+		}
+	}
+
+	return scrubbed
+}
+
+// vcrScrubBody returns body, with the Content-Type given by header, with the
+// values of credential fields redacted. Form bodies are scrubbed by their
+// Content-Type and any other valid JSON body as JSON. Bodies without
+// credential fields are returned unchanged.
+func vcrScrubBody(header http.Header, body []byte) string {
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	if mediaType == "application/x-www-form-urlencoded" {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			// Do not risk saving credentials in a malformed form.
+			return vcrRedacted
+		}
+
+		scrubbed := false
+		for _, field := range vcrScrubbedFields {
+			if values.Has(field) {
+				values.Set(field, vcrRedacted)
+				scrubbed = true
+			}
+		}
+		if !scrubbed {
+			return string(body)
+		}
+
+		return values.Encode()
+	}
+
+	if !json.Valid(body) {
+		return string(body)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil || !vcrScrubJSON(value) {
+		return string(body)
+	}
+
+	buf, err := json.Marshal(value)
+	if err != nil {
+		return vcrRedacted
+	}
+
+	return string(buf)
+}
+
+// vcrScrubJSON redacts the credential fields of the objects in value, which
+// is a decoded JSON value, and reports whether any were found.
+func vcrScrubJSON(value any) bool {
+	scrubbed := false
+
+	switch value := value.(type) {
+	case map[string]any:
+		for key, v := range value {
+			if slices.Contains(vcrScrubbedFields, key) {
+				value[key] = vcrRedacted
+				scrubbed = true
+				continue
+			}
+			if vcrScrubJSON(v) {
+				scrubbed = true
+			}
+		}
+	case []any:
+		for _, v := range value {
+			if vcrScrubJSON(v) {
+				scrubbed = true
+			}
+		}
+	}
+
+	return scrubbed
+}
@@ -0,0 +1,162 @@
+package todo
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestVCRRecordAndReplay(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		switch r.URL.Path {
+		case "/oauth/token":
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"access_token":"secret-token","token_type":"Bearer","expires_in":3600}`)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"call":`+strconv.Itoa(int(n))+`}`)
+		}
+	}))
+	defer server.Close()
+
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	roundTrip := func(t *testing.T, rt http.RoundTripper, method, path, contentType, body string) string {
+		t.Helper()
+
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		req.Header.Set("Authorization", "Bearer provider-token")
+
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		buf, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(buf)
+	}
+
+	// Record through two transports, as two provider configurations would.
+	for _, path := range []string{"/oauth/token", "/api/todo"} {
+		rt, err := newVCRTransport(vcrModeRecord, cassette, http.DefaultTransport)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if path == "/oauth/token" {
+			roundTrip(t, rt, http.MethodPost, path, "application/x-www-form-urlencoded", "grant_type=client_credentials&client_secret=hunter2")
+		} else {
+			roundTrip(t, rt, http.MethodGet, path, "", "")
+			roundTrip(t, rt, http.MethodGet, path, "", "")
+		}
+	}
+
+	info, err := os.Stat(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o600 {
+		t.Errorf("cassette mode = %o, want 600", mode)
+	}
+
+	buf, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"secret-token", "hunter2", "provider-token"} {
+		if strings.Contains(string(buf), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+
+	var recorded vcrCassette
+	if err := json.Unmarshal(buf, &recorded); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorded.Interactions) != 3 {
+		t.Fatalf("cassette has %d interactions, want 3", len(recorded.Interactions))
+	}
+
+	// Replay through two transports, which continue where the first one
+	// stopped instead of starting over.
+	calls.Store(0)
+	first, err := newVCRTransport(vcrModeReplay, cassette, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := roundTrip(t, first, http.MethodPost, "/oauth/token", "application/x-www-form-urlencoded", "grant_type=client_credentials&client_secret=hunter2"); !strings.Contains(got, vcrRedacted) {
+		t.Errorf("replayed token response = %s, want a redacted token", got)
+	}
+	if got := roundTrip(t, first, http.MethodGet, "/api/todo", "", ""); got != `{"call":2}` {
+		t.Errorf("first replayed list = %s", got)
+	}
+
+	second, err := newVCRTransport(vcrModeReplay, cassette, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := roundTrip(t, second, http.MethodGet, "/api/todo", "", ""); got != `{"call":3}` {
+		t.Errorf("second replayed list = %s", got)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/api/todo", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := second.RoundTrip(req); err == nil {
+		t.Error("replaying past the end of the cassette succeeded")
+	}
+	if n := calls.Load(); n != 0 {
+		t.Errorf("replay sent %d requests to the server", n)
+	}
+}
+
+func TestVCRScrubBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+	}{
+		{name: "json token", contentType: "application/json", body: `{"access_token":"a","expires_in":60}`, want: `{"access_token":"REDACTED","expires_in":60}`},
+		{name: "nested json", contentType: "application/json", body: `{"data":[{"token":"t"}]}`, want: `{"data":[{"token":"REDACTED"}]}`},
+		{name: "json without content type", body: `{"password":"p"}`, want: `{"password":"REDACTED"}`},
+		{name: "json without secrets", contentType: "application/json", body: `{"text":"Buy milk", "n": 1.50}`, want: `{"text":"Buy milk", "n": 1.50}`},
+		{name: "form", contentType: "application/x-www-form-urlencoded", body: "client_secret=s&grant_type=client_credentials", want: "client_secret=REDACTED&grant_type=client_credentials"},
+		{name: "form without secrets", contentType: "application/x-www-form-urlencoded", body: "grant_type=client_credentials", want: "grant_type=client_credentials"},
+		{name: "malformed form", contentType: "application/x-www-form-urlencoded", body: "client_secret=%zz", want: vcrRedacted},
+		{name: "text", contentType: "text/plain", body: "token=abc", want: "token=abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := make(http.Header)
+			if tt.contentType != "" {
+				header.Set("Content-Type", tt.contentType)
+			}
+
+			if got := vcrScrubBody(header, []byte(tt.body)); got != tt.want {
+				t.Errorf("vcrScrubBody() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Package todotest provides helpers for writing acceptance tests of Terraform
+// configurations that use the todo provider, such as modules built on top of
+// it, without a real todo API server.
+package todotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/sudomateo/terraform-provider-todo/internal/mockapi"
+	"github.com/sudomateo/terraform-provider-todo/todo"
+	api "github.com/sudomateo/todo/todo"
+	"github.com/sudomateo/todo/todo/stores/todomemory"
+)
+
+// ProviderFactories returns provider factories for the todo provider, to be
+// used as the ProtoV6ProviderFactories of a terraform-plugin-testing test
+// case.
+func ProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"todo": providerserver.NewProtocol6WithError(todo.New()),
+	}
+}
+
+// Server is an in-memory todo API server.
+type Server struct {
+	// URL is the base URL of the server, to be used as the provider host.
+	URL string
+
+	server *httptest.Server
+}
+
+// NewServer starts an in-memory todo API server that is closed when the test
+// finishes. The server starts without any todos.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	server := httptest.NewServer(mockapi.NewHandler(todomemory.NewStore()))
+	t.Cleanup(server.Close)
+
+	return &Server{
+		URL:    server.URL,
+		server: server,
+	}
+}
+
+// ProviderConfig returns a provider block configuring the todo provider to
+// use the server.
+func (s *Server) ProviderConfig() string {
+	return fmt.Sprintf("provider \"todo\" {\n  endpoint = %q\n}\n", s.URL)
+}
+
+// Seed creates the given todos on the server and returns them, failing the
+// test on error.
+func (s *Server) Seed(t testing.TB, params ...api.TodoCreateParams) []api.Todo {
+	t.Helper()
+
+	todos := make([]api.Todo, 0, len(params))
+	for _, p := range params {
+		body, err := json.Marshal(p)
+		if err != nil {
+			t.Fatalf("encoding todo %q: %v", p.Text, err)
+		}
+
+		resp, err := s.server.Client().Post(s.URL+"/api/todo", "application/json", bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("creating todo %q: %v", p.Text, err)
+		}
+
+		var td api.Todo
+		err = json.NewDecoder(resp.Body).Decode(&td)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("creating todo %q: received status code %v", p.Text, resp.StatusCode)
+		}
+		if err != nil {
+			t.Fatalf("decoding todo %q: %v", p.Text, err)
+		}
+
+		todos = append(todos, td)
+	}
+
+	return todos
+}
+
+// Todos returns the todos currently on the server, failing the test on error.
+func (s *Server) Todos(t testing.TB) []api.Todo {
+	t.Helper()
+
+	resp, err := s.server.Client().Get(s.URL + "/api/todo")
+	if err != nil {
+		t.Fatalf("listing todos: %v", err)
+	}
+	defer resp.Body.Close()
+
+	todos := make([]api.Todo, 0)
+	if err := json.NewDecoder(resp.Body).Decode(&todos); err != nil {
+		t.Fatalf("decoding todos: %v", err)
+	}
+
+	return todos
+}